@@ -0,0 +1,8 @@
+data "migadu_domain" "domain" {
+  name = "example.com"
+}
+
+# international domain names are supported
+data "migadu_domain" "idn" {
+  name = "bücher.example"
+}
@@ -0,0 +1,14 @@
+data "migadu_domain_diagnostics" "diagnostics" {
+  domain_name = "example.com"
+}
+
+# fail the plan until all DNS records are set up correctly
+data "migadu_domain_diagnostics" "strict" {
+  domain_name   = "example.com"
+  fail_on_error = true
+}
+
+# international domain names are supported
+data "migadu_domain_diagnostics" "idn" {
+  domain_name = "bücher.example"
+}
@@ -0,0 +1,22 @@
+data "migadu_domain_dns_records" "records" {
+  domain_name = "example.com"
+}
+
+# international domain names are supported
+data "migadu_domain_dns_records" "idn" {
+  domain_name = "bücher.example"
+}
+
+# feed the records into a DNS provider
+resource "dns_provider_record" "migadu" {
+  for_each = {
+    for record in data.migadu_domain_dns_records.records.records : "${record.type}-${record.name}-${record.value}" => record
+  }
+
+  zone     = "example.com"
+  type     = each.value.type
+  name     = each.value.name
+  value    = each.value.value
+  priority = each.value.priority
+  ttl      = each.value.ttl
+}
@@ -0,0 +1 @@
+data "migadu_domains" "domains" {}
@@ -0,0 +1,10 @@
+data "migadu_mailbox_forwardings" "forwardings" {
+  domain_name = "example.com"
+  local_part  = "some-name"
+}
+
+# international domain names are supported
+data "migadu_mailbox_forwardings" "idn" {
+  domain_name = "bücher.example"
+  local_part  = "some-name"
+}
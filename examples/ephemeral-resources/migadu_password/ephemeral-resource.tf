@@ -0,0 +1,22 @@
+ephemeral "migadu_password" "example" {}
+
+resource "migadu_mailbox" "example" {
+  name                = "Mailbox Name"
+  domain_name         = "example.com"
+  local_part          = "some-mailbox"
+  password_wo         = ephemeral.migadu_password.example.result
+  password_wo_version = 1 # increment to generate and send a new password
+}
+
+# shorter password without special characters
+ephemeral "migadu_password" "simple" {
+  length  = 16
+  special = false
+}
+
+# passphrase of random pronounceable words, e.g. 'Dabeku-lomiri-...-fezoha7'
+ephemeral "migadu_password" "passphrase" {
+  passphrase = true
+  words      = 5
+  separator  = "-"
+}
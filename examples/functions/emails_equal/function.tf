@@ -0,0 +1,4 @@
+# returns true
+output "emails_equal" {
+  value = provider::migadu::emails_equal("info@bücher.example", "INFO@xn--bcher-kva.example")
+}
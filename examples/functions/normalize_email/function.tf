@@ -0,0 +1,4 @@
+# returns "info@xn--bcher-kva.example"
+output "normalized_email" {
+  value = provider::migadu::normalize_email("Info@Bücher.example")
+}
@@ -0,0 +1,11 @@
+locals {
+  # returns { local_part = "info", domain = "bücher.example" }
+  address = provider::migadu::parse_email("info@bücher.example")
+}
+
+resource "migadu_mailbox" "example" {
+  name        = "Info"
+  domain_name = local.address.domain
+  local_part  = local.address.local_part
+  password    = "Sup3r_s3cr3tPassw0rd"
+}
@@ -0,0 +1,9 @@
+# returns true
+output "matches" {
+  value = provider::migadu::rewrite_rule_matches("sec-*", "sec-team")
+}
+
+# returns false
+output "no_match" {
+  value = provider::migadu::rewrite_rule_matches("sec-*", "security")
+}
@@ -0,0 +1,35 @@
+resource "migadu_rewrite_rule_set" "example" {
+  domain_name = "example.com"
+  rules = [
+    {
+      name            = "security"
+      local_part_rule = "sec-*"
+      destinations    = ["security@example.com"]
+    },
+    {
+      name            = "sales"
+      local_part_rule = "*-sales"
+      destinations    = ["sales@example.com", "ceo@example.com"]
+    },
+  ]
+}
+
+# returns ["security@example.com"]
+output "security" {
+  value = provider::migadu::rewrite_rule_resolve(migadu_rewrite_rule_set.example.rules, "sec-team@example.com")
+}
+
+# rules can be tested without touching the API, e.g. in 'terraform test' assertions
+locals {
+  rules = [
+    {
+      local_part_rule = "sec-*"
+      destinations    = ["security@example.com"]
+    },
+  ]
+}
+
+# returns []
+output "unmatched" {
+  value = provider::migadu::rewrite_rule_resolve(local.rules, "info@example.com")
+}
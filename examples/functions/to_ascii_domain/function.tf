@@ -0,0 +1,4 @@
+# returns "xn--bcher-kva.example"
+output "ascii_domain" {
+  value = provider::migadu::to_ascii_domain("Bücher.example")
+}
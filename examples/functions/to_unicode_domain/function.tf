@@ -0,0 +1,4 @@
+# returns "bücher.example"
+output "unicode_domain" {
+  value = provider::migadu::to_unicode_domain("xn--bcher-kva.example")
+}
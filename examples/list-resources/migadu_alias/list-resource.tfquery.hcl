@@ -0,0 +1,16 @@
+list "migadu_alias" "example" {
+  provider = migadu
+
+  config {
+    domain_name = "example.com"
+  }
+}
+
+# international domain names are supported
+list "migadu_alias" "idna" {
+  provider = migadu
+
+  config {
+    domain_name = "bücher.example"
+  }
+}
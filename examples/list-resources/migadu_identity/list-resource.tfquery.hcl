@@ -0,0 +1,27 @@
+# all identities of all mailboxes in a domain
+list "migadu_identity" "example" {
+  provider = migadu
+
+  config {
+    domain_name = "example.com"
+  }
+}
+
+# identities of a single mailbox
+list "migadu_identity" "mailbox" {
+  provider = migadu
+
+  config {
+    domain_name = "example.com"
+    local_part  = "some-mailbox"
+  }
+}
+
+# international domain names are supported
+list "migadu_identity" "idna" {
+  provider = migadu
+
+  config {
+    domain_name = "bücher.example"
+  }
+}
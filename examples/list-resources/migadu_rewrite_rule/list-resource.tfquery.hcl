@@ -0,0 +1,16 @@
+list "migadu_rewrite_rule" "example" {
+  provider = migadu
+
+  config {
+    domain_name = "example.com"
+  }
+}
+
+# international domain names are supported
+list "migadu_rewrite_rule" "idna" {
+  provider = migadu
+
+  config {
+    domain_name = "bücher.example"
+  }
+}
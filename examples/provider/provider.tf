@@ -10,6 +10,41 @@ provider "migadu" {
   endpoint = "https://api.migadu.com/v1/"
 }
 
+# read the token from a file, e.g. a mounted secret
+provider "migadu" {
+  username   = "some-name@example.com"
+  token_file = "/run/secrets/migadu-token"
+}
+
+# read the token from a credential helper
+provider "migadu" {
+  username      = "some-name@example.com"
+  token_command = "pass show migadu/token"
+}
+
+# read username and token from a profile of ~/.config/migadu/credentials, e.g.:
+#
+#   [work]
+#   username      = some-name@example.com
+#   token_command = pass show migadu/work
+provider "migadu" {
+  profile = "work"
+}
+
+# verify endpoint, username and token while configuring the provider
+provider "migadu" {
+  username             = "some-name@example.com"
+  token                = "your-super-secret-token-that-should-not-be-committed-in-plaintext"
+  validate_credentials = true
+}
+
+# adopt mailboxes, aliases, identities and rewrite rules that already exist instead of failing to create them
+provider "migadu" {
+  username       = "some-name@example.com"
+  token          = "your-super-secret-token-that-should-not-be-committed-in-plaintext"
+  adopt_existing = true
+}
+
 # override the default rate limit (defaults to 60 requests per 2m)
 provider "migadu" {
   username      = "some-name@example.com"
@@ -24,3 +59,56 @@ provider "migadu" {
   token      = "your-super-secret-token-that-should-not-be-committed-in-plaintext"
   rate_limit = 0
 }
+
+# send at most 2 API requests at the same time
+provider "migadu" {
+  username                = "some-name@example.com"
+  token                   = "your-super-secret-token-that-should-not-be-committed-in-plaintext"
+  max_concurrent_requests = 2
+}
+
+# rate and concurrency limits are shared by all provider configurations using the same account
+provider "migadu" {
+  alias    = "other"
+  username = "some-name@example.com"
+  token    = "your-super-secret-token-that-should-not-be-committed-in-plaintext"
+}
+
+# retry failed API requests up to 5 times, waiting between 2s and 1m between retries
+provider "migadu" {
+  username          = "some-name@example.com"
+  token             = "your-super-secret-token-that-should-not-be-committed-in-plaintext"
+  max_retries       = 5
+  retry_min_backoff = "2s"
+  retry_max_backoff = "1m"
+}
+
+# disable retries
+provider "migadu" {
+  username    = "some-name@example.com"
+  token       = "your-super-secret-token-that-should-not-be-committed-in-plaintext"
+  max_retries = 0
+}
+
+# read all aliases, mailboxes and rewrite rules of a domain at once to speed up refreshing many resources
+provider "migadu" {
+  username   = "some-name@example.com"
+  token      = "your-super-secret-token-that-should-not-be-committed-in-plaintext"
+  read_cache = true
+}
+
+# send all API requests through a TLS-intercepting proxy and trust its certificate authority
+provider "migadu" {
+  username       = "some-name@example.com"
+  token          = "your-super-secret-token-that-should-not-be-committed-in-plaintext"
+  proxy_url      = "http://proxy.example.com:3128"
+  ca_bundle_file = "/etc/ssl/certs/proxy-ca.pem"
+}
+
+# authenticate with a client certificate
+provider "migadu" {
+  username    = "some-name@example.com"
+  token       = "your-super-secret-token-that-should-not-be-committed-in-plaintext"
+  client_cert = file("client.pem")
+  client_key  = file("client-key.pem")
+}
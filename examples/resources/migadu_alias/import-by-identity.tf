@@ -0,0 +1,7 @@
+import {
+  to = migadu_alias.alias
+  identity = {
+    local_part  = "some-alias"
+    domain_name = "example.com"
+  }
+}
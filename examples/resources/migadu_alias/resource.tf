@@ -18,3 +18,31 @@ resource "migadu_alias" "idn" {
     "second@bücher.example",
   ]
 }
+
+# limit the duration of each operation including retries, defaults to 5m
+resource "migadu_alias" "timeouts" {
+  domain_name = "example.com"
+  local_part  = "some-name"
+
+  destinations = [
+    "first@example.com",
+  ]
+
+  timeouts {
+    create = "2m"
+    read   = "30s"
+    update = "2m"
+    delete = "1m"
+  }
+}
+
+# take over an alias that was created by hand instead of failing to create it
+resource "migadu_alias" "adopted" {
+  domain_name    = "example.com"
+  local_part     = "some-name"
+  adopt_existing = true
+
+  destinations = [
+    "first@example.com",
+  ]
+}
@@ -0,0 +1,8 @@
+import {
+  to = migadu_alias_destination.destination
+  identity = {
+    local_part  = "some-alias"
+    domain_name = "example.com"
+    destination = "someone@example.com"
+  }
+}
@@ -0,0 +1,3 @@
+# migadu_alias_destination resources can be imported by specifying the local part
+# and the domain name of the alias as well as the destination to import.
+terraform import migadu_alias_destination.destination 'local_part@domain_name/destination'
@@ -0,0 +1,12 @@
+resource "migadu_alias_destination" "example" {
+  domain_name = "example.com"
+  local_part  = "team"
+  destination = "someone@example.com"
+}
+
+# international domain names are supported
+resource "migadu_alias_destination" "idn" {
+  domain_name = "bücher.example"
+  local_part  = "team"
+  destination = "someone@bücher.example"
+}
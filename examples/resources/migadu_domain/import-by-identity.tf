@@ -0,0 +1,6 @@
+import {
+  to = migadu_domain.domain
+  identity = {
+    name = "example.com"
+  }
+}
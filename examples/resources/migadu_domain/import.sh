@@ -0,0 +1,2 @@
+# migadu_domain resources can be imported by specifying the domain name.
+terraform import migadu_domain.domain 'domain_name'
@@ -0,0 +1,16 @@
+resource "migadu_domain" "example" {
+  name                = "example.com"
+  description         = "Primary domain"
+  hosted_dns          = false
+  spam_aggressiveness = "default"
+  greylisting_enabled = true
+
+  catchall_destinations = [
+    "catchall@example.com",
+  ]
+}
+
+# international domain names are supported
+resource "migadu_domain" "idn" {
+  name = "bücher.example"
+}
@@ -0,0 +1,8 @@
+import {
+  to = migadu_identity.identity
+  identity = {
+    local_part  = "some-mailbox"
+    domain_name = "example.com"
+    identity    = "some-identity"
+  }
+}
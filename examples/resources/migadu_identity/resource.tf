@@ -40,3 +40,29 @@ resource "migadu_identity" "custom" {
   password_use = "custom" # use identity user/password
   password     = "Sup3r_s3cr3T"
 }
+
+# application specific password that is kept out of the state, requires Terraform 1.11 or later
+resource "migadu_identity" "write_only" {
+  domain_name         = "example.com"
+  local_part          = "some-mailbox"
+  identity            = "some-identity"
+  name                = "Some Name"
+  password_use        = "custom"
+  password_wo         = "Sup3r_s3cr3T"
+  password_wo_version = 1 # increment to send a new password
+}
+
+# limit the duration of each operation including retries, defaults to 5m
+resource "migadu_identity" "timeouts" {
+  domain_name = "example.com"
+  local_part  = "some-mailbox"
+  identity    = "some-identity"
+  name        = "Some Name"
+
+  timeouts {
+    create = "2m"
+    read   = "30s"
+    update = "2m"
+    delete = "1m"
+  }
+}
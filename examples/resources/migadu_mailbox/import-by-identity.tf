@@ -0,0 +1,7 @@
+import {
+  to = migadu_mailbox.mailbox
+  identity = {
+    local_part  = "some-mailbox"
+    domain_name = "example.com"
+  }
+}
@@ -5,6 +5,15 @@ resource "migadu_mailbox" "example" {
   password    = "Sup3r_s3cr3T"
 }
 
+# keep the password out of the state, requires Terraform 1.11 or later
+resource "migadu_mailbox" "write_only" {
+  name                = "Mailbox Name"
+  domain_name         = "example.com"
+  local_part          = "some-mailbox"
+  password_wo         = "Sup3r_s3cr3T"
+  password_wo_version = 1 # increment to send a new password
+}
+
 # send invitation to users and let them set password themselves
 resource "migadu_mailbox" "invitation" {
   name                    = "Mailbox Name"
@@ -21,3 +30,27 @@ resource "migadu_mailbox" "idn" {
   local_part  = "some-mailbox"
   password    = "Sup3r_s3cr3T"
 }
+
+# limit the duration of each operation including retries, defaults to 5m
+resource "migadu_mailbox" "timeouts" {
+  name        = "Mailbox Name"
+  domain_name = "example.com"
+  local_part  = "some-mailbox"
+  password    = "Sup3r_s3cr3T"
+
+  timeouts {
+    create = "2m"
+    read   = "30s"
+    update = "2m"
+    delete = "1m"
+  }
+}
+
+# take over a mailbox that already exists, e.g. after a previous apply timed out
+resource "migadu_mailbox" "adopted" {
+  name           = "Mailbox Name"
+  domain_name    = "example.com"
+  local_part     = "some-mailbox"
+  password       = "Sup3r_s3cr3T"
+  adopt_existing = true
+}
@@ -0,0 +1,3 @@
+# migadu_mailbox_autoresponder resources can be imported by specifying the local part
+# and the domain name of the mailbox.
+terraform import migadu_mailbox_autoresponder.autoresponder 'local_part@domain_name'
@@ -0,0 +1,25 @@
+resource "migadu_mailbox" "example" {
+  name        = "Mailbox Name"
+  domain_name = "example.com"
+  local_part  = "some-mailbox"
+  password    = "Sup3r_s3cr3T"
+
+  # leave the automatic response to the migadu_mailbox_autoresponder resource
+  manage_autoresponder = false
+}
+
+resource "migadu_mailbox_autoresponder" "example" {
+  domain_name = migadu_mailbox.example.domain_name
+  local_part  = migadu_mailbox.example.local_part
+  subject     = "Out of office"
+  body        = "I am on vacation until the end of the month."
+  expires_on  = "2030-12-31"
+}
+
+# international domain names are supported
+resource "migadu_mailbox_autoresponder" "idn" {
+  domain_name = "bücher.example"
+  local_part  = "some-mailbox"
+  subject     = "Out of office"
+  body        = "I am on vacation until the end of the month."
+}
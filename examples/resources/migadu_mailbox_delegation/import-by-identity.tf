@@ -0,0 +1,8 @@
+import {
+  to = migadu_mailbox_delegation.delegation
+  identity = {
+    local_part  = "some-mailbox"
+    domain_name = "example.com"
+    delegation  = "someone@example.com"
+  }
+}
@@ -0,0 +1,3 @@
+# migadu_mailbox_delegation resources can be imported by specifying the local part
+# and the domain name of the mailbox as well as the delegation to import.
+terraform import migadu_mailbox_delegation.delegation 'local_part@domain_name/delegation'
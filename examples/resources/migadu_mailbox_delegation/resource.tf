@@ -0,0 +1,12 @@
+resource "migadu_mailbox_delegation" "example" {
+  domain_name = "example.com"
+  local_part  = "some-name"
+  delegation  = "assistant@example.com"
+}
+
+# international domain names are supported
+resource "migadu_mailbox_delegation" "idn" {
+  domain_name = "bücher.example"
+  local_part  = "some-name"
+  delegation  = "assistant@bücher.example"
+}
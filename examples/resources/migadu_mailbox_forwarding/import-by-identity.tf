@@ -0,0 +1,8 @@
+import {
+  to = migadu_mailbox_forwarding.forwarding
+  identity = {
+    local_part  = "some-mailbox"
+    domain_name = "example.com"
+    destination = "someone@example.org"
+  }
+}
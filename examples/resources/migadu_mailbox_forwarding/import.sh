@@ -0,0 +1,3 @@
+# migadu_mailbox_forwarding resources can be imported by specifying the local part
+# and the domain name of the mailbox as well as the destination address of the forwarding.
+terraform import migadu_mailbox_forwarding.forwarding 'local_part@domain_name/destination'
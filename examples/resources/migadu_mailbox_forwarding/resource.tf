@@ -0,0 +1,20 @@
+resource "migadu_mailbox_forwarding" "example" {
+  domain_name = "example.com"
+  local_part  = "some-name"
+  destination = "someone@example.org"
+}
+
+resource "migadu_mailbox_forwarding" "temporary" {
+  domain_name        = "example.com"
+  local_part         = "some-name"
+  destination        = "someone-else@example.org"
+  expires_on         = "2030-12-31"
+  remove_upon_expiry = true
+}
+
+# international domain names are supported
+resource "migadu_mailbox_forwarding" "idn" {
+  domain_name = "bücher.example"
+  local_part  = "some-name"
+  destination = "someone@bücher.example"
+}
@@ -0,0 +1,9 @@
+import {
+  to = migadu_mailbox_list_entry.entry
+  identity = {
+    local_part  = "some-mailbox"
+    domain_name = "example.com"
+    list        = "sender_denylist"
+    address     = "spam@example.org"
+  }
+}
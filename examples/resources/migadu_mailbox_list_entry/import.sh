@@ -0,0 +1,3 @@
+# migadu_mailbox_list_entry resources can be imported by specifying the local part
+# and the domain name of the mailbox as well as the list and the address to import.
+terraform import migadu_mailbox_list_entry.entry 'local_part@domain_name/list/address'
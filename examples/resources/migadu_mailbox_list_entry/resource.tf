@@ -0,0 +1,14 @@
+resource "migadu_mailbox_list_entry" "example" {
+  domain_name = "example.com"
+  local_part  = "some-name"
+  list        = "sender_denylist"
+  address     = "spam@example.org"
+}
+
+# international domain names are supported
+resource "migadu_mailbox_list_entry" "idn" {
+  domain_name = "bücher.example"
+  local_part  = "some-name"
+  list        = "sender_allowlist"
+  address     = "friend@bücher.example"
+}
@@ -0,0 +1,7 @@
+import {
+  to = migadu_rewrite_rule.rewrite
+  identity = {
+    domain_name = "example.com"
+    name        = "some-rule"
+  }
+}
@@ -20,3 +20,21 @@ resource "migadu_rewrite_rule" "idn" {
     "second@bücher.example",
   ]
 }
+
+# limit the duration of each operation including retries, defaults to 5m
+resource "migadu_rewrite_rule" "timeouts" {
+  domain_name     = "example.com"
+  name            = "security-mails"
+  local_part_rule = "sec-*"
+
+  destinations = [
+    "first@example.com",
+  ]
+
+  timeouts {
+    create = "2m"
+    read   = "30s"
+    update = "2m"
+    delete = "1m"
+  }
+}
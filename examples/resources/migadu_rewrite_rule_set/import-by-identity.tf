@@ -0,0 +1,6 @@
+import {
+  to = migadu_rewrite_rule_set.rules
+  identity = {
+    domain_name = "example.com"
+  }
+}
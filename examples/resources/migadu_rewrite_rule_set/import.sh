@@ -0,0 +1,3 @@
+# migadu_rewrite_rule_set resources can be imported by specifying the domain name.
+# All existing rewrite rules of the domain are imported in their current order.
+terraform import migadu_rewrite_rule_set.rules 'domain_name'
@@ -0,0 +1,43 @@
+resource "migadu_rewrite_rule_set" "example" {
+  domain_name = "example.com"
+
+  rules = [
+    {
+      name            = "security-mails"
+      local_part_rule = "sec-*"
+      destinations    = ["security@example.com"]
+    },
+    {
+      name            = "catch-the-rest"
+      local_part_rule = "*"
+      destinations    = ["first@example.com", "second@example.com"]
+    },
+  ]
+}
+
+# rewrite rules of the domain that are not listed are deleted
+resource "migadu_rewrite_rule_set" "authoritative" {
+  domain_name     = "example.org"
+  delete_unlisted = true
+
+  rules = [
+    {
+      name            = "security-mails"
+      local_part_rule = "sec-*"
+      destinations    = ["security@example.org"]
+    },
+  ]
+}
+
+# international domain names are supported
+resource "migadu_rewrite_rule_set" "idn" {
+  domain_name = "bücher.example"
+
+  rules = [
+    {
+      name            = "security-mails"
+      local_part_rule = "sec-*"
+      destinations    = ["security@bücher.example"]
+    },
+  ]
+}
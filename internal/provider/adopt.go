@@ -0,0 +1,38 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/migadu-client.go/client"
+	"net/http"
+)
+
+// adoptExisting returns whether a resource adopts existing objects. The 'adopt_existing' attribute of the resource
+// takes precedence over the default of the provider.
+func adoptExisting(attribute types.Bool, providerDefault bool) bool {
+	if attribute.IsNull() || attribute.IsUnknown() {
+		return providerDefault
+	}
+	return attribute.ValueBool()
+}
+
+// isConflictError returns whether the given error is a conflict response of the Migadu API, which it returns when
+// creating objects that already exist.
+func isConflictError(err error) bool {
+	return isRequestErrorStatus(err, http.StatusConflict)
+}
+
+// isNotFoundError returns whether the given error is a not found response of the Migadu API.
+func isNotFoundError(err error) bool {
+	return isRequestErrorStatus(err, http.StatusNotFound)
+}
+
+func isRequestErrorStatus(err error, statusCode int) bool {
+	var requestError *client.RequestError
+	return errors.As(err, &requestError) && requestError.StatusCode == statusCode
+}
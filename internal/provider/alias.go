@@ -6,12 +6,23 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
 )
 
+// aliasAPIAttributes maps API error fields to the attributes of the alias resource.
+var aliasAPIAttributes = map[string]string{
+	"local_part":         "local_part",
+	"destinations":       "destinations",
+	"is_internal":        "is_internal",
+	"expireable":         "expirable",
+	"expires_on":         "expires_on",
+	"remove_upon_expiry": "remove_upon_expiry",
+}
+
 func CreateAliasID(localPart types.String, domainName custom_types.DomainNameValue) string {
 	return CreateAliasIDString(localPart.ValueString(), domainName.ValueString())
 }
@@ -20,32 +31,20 @@ func CreateAliasIDString(localPart string, domainName string) string {
 	return fmt.Sprintf("%s@%s", localPart, domainName)
 }
 
-func AliasCreateError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Creating Alias",
-		standardAPIErrorDetail(err),
-	)
+func AliasCreateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Creating Alias", err, aliasAPIAttributes)
 }
 
-func AliasReadError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Reading Alias",
-		standardAPIErrorDetail(err),
-	)
+func AliasReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Alias", err, aliasAPIAttributes)
 }
 
-func AliasUpdateError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Updating Alias",
-		standardAPIErrorDetail(err),
-	)
+func AliasUpdateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Updating Alias", err, aliasAPIAttributes)
 }
 
-func AliasDeleteError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Deleting Alias",
-		standardAPIErrorDetail(err),
-	)
+func AliasDeleteError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Deleting Alias", err, aliasAPIAttributes)
 }
 
 func AliasImportError(id string) diag.Diagnostic {
@@ -54,3 +53,10 @@ func AliasImportError(id string) diag.Diagnostic {
 		standardImportErrorDetail("local_part@domain_name", id),
 	)
 }
+
+func AliasImportIdentityError() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Alias",
+		standardImportIdentityErrorDetail("local_part", "domain_name"),
+	)
+}
@@ -148,6 +148,8 @@ func (d *AliasDataSource) Configure(_ context.Context, request datasource.Config
 }
 
 func (d *AliasDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var data AliasDataSourceModel
 	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
 	if response.Diagnostics.HasError() {
@@ -156,7 +158,7 @@ func (d *AliasDataSource) Read(ctx context.Context, request datasource.ReadReque
 
 	alias, err := d.migaduClient.GetAlias(ctx, data.DomainName.ValueString(), data.LocalPart.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(AliasReadError(err))
+		response.Diagnostics.Append(AliasReadError(ctx, err)...)
 		return
 	}
 
@@ -0,0 +1,64 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+// aliasDestinationAPIAttributes maps API error fields to the attributes of the alias destination resource.
+var aliasDestinationAPIAttributes = map[string]string{
+	"destinations": "destination",
+}
+
+func CreateAliasDestinationID(localPart types.String, domainName custom_types.DomainNameValue, destination custom_types.EmailAddressValue) string {
+	return CreateAliasDestinationIDString(localPart.ValueString(), domainName.ValueString(), destination.ValueString())
+}
+
+func CreateAliasDestinationIDString(localPart, domainName, destination string) string {
+	return fmt.Sprintf("%s@%s/%s", localPart, domainName, destination)
+}
+
+// removeEmailAddress returns all emails that are not semantically equal to the given email.
+func removeEmailAddress(ctx context.Context, emails []string, email custom_types.EmailAddressValue) []string {
+	remaining := make([]string, 0, len(emails))
+	for _, elem := range emails {
+		if equal, _ := custom_types.NewEmailAddressValue(elem).StringSemanticEquals(ctx, email); !equal {
+			remaining = append(remaining, elem)
+		}
+	}
+	return remaining
+}
+
+func AliasDestinationCreateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Creating Alias Destination", err, aliasDestinationAPIAttributes)
+}
+
+func AliasDestinationReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Alias Destination", err, aliasDestinationAPIAttributes)
+}
+
+func AliasDestinationDeleteError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Deleting Alias Destination", err, aliasDestinationAPIAttributes)
+}
+
+func AliasDestinationImportError(id string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Alias Destination",
+		standardImportErrorDetail("local_part@domain_name/destination", id),
+	)
+}
+
+func AliasDestinationImportIdentityError() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Alias Destination",
+		standardImportIdentityErrorDetail("local_part", "domain_name", "destination"),
+	)
+}
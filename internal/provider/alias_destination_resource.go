@@ -0,0 +1,355 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+	"net/http"
+	"strings"
+)
+
+var (
+	_ resource.Resource                = (*AliasDestinationResource)(nil)
+	_ resource.ResourceWithConfigure   = (*AliasDestinationResource)(nil)
+	_ resource.ResourceWithImportState = (*AliasDestinationResource)(nil)
+	_ resource.ResourceWithIdentity    = (*AliasDestinationResource)(nil)
+)
+
+func NewAliasDestinationResource() resource.Resource {
+	return &AliasDestinationResource{}
+}
+
+type AliasDestinationResource struct {
+	MigaduClient *client.MigaduClient
+	ReadCache    *ReadCache
+}
+
+type AliasDestinationResourceModel struct {
+	ID          types.String                   `tfsdk:"id"`
+	LocalPart   types.String                   `tfsdk:"local_part"`
+	DomainName  custom_types.DomainNameValue   `tfsdk:"domain_name"`
+	Destination custom_types.EmailAddressValue `tfsdk:"destination"`
+}
+
+type AliasDestinationResourceIdentityModel struct {
+	LocalPart   types.String `tfsdk:"local_part"`
+	DomainName  types.String `tfsdk:"domain_name"`
+	Destination types.String `tfsdk:"destination"`
+}
+
+func (r *AliasDestinationResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_alias_destination"
+}
+
+func (r *AliasDestinationResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Provides a single destination of an existing alias. Other destinations of the alias are left untouched. Do not use this resource together with the 'destinations' attribute of the 'migadu_alias' resource for the same alias.",
+		MarkdownDescription: "Provides a single destination of an existing alias. Other destinations of the alias are left untouched. Do not use this resource together with the `destinations` attribute of the `migadu_alias` resource for the same alias.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Contains the value 'local_part@domain_name/destination'.",
+				MarkdownDescription: "Contains the value `local_part@domain_name/destination`.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"local_part": schema.StringAttribute{
+				Description:         "The local part of the alias.",
+				MarkdownDescription: "The local part of the alias.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain name of the alias.",
+				MarkdownDescription: "The domain name of the alias.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"destination": schema.StringAttribute{
+				Description:         "The email address to add to the destinations of the alias.",
+				MarkdownDescription: "The email address to add to the destinations of the alias.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.EmailAddressType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *AliasDestinationResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the alias.",
+				RequiredForImport: true,
+			},
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the alias.",
+				RequiredForImport: true,
+			},
+			"destination": identityschema.StringAttribute{
+				Description:       "The email address to add to the destinations of the alias.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *AliasDestinationResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if providerData, ok := request.ProviderData.(*MigaduProviderData); ok {
+		r.MigaduClient = providerData.Client
+		r.ReadCache = providerData.ReadCache
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.MigaduProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (r *AliasDestinationResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var plan AliasDestinationResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	unlock := lockObject("alias", plan.LocalPart.ValueString(), plan.DomainName.ValueString())
+	defer unlock()
+
+	alias, err := r.MigaduClient.GetAlias(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(AliasDestinationCreateError(ctx, err)...)
+		return
+	}
+
+	destinations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, alias.Destinations)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if !destinations.Contains(ctx, plan.Destination) {
+		alias.Destinations = append(alias.Destinations, plan.Destination.ValueString())
+
+		_, err = r.MigaduClient.UpdateAlias(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), alias)
+		if err != nil {
+			response.Diagnostics.Append(AliasDestinationCreateError(ctx, err)...)
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(CreateAliasDestinationID(plan.LocalPart, plan.DomainName, plan.Destination))
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newAliasDestinationResourceIdentityModel(&plan))...)
+}
+
+func (r *AliasDestinationResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state AliasDestinationResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	alias, err := r.ReadCache.GetAlias(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
+	if err != nil {
+		var requestError *client.RequestError
+		if errors.As(err, &requestError) {
+			if requestError.StatusCode == http.StatusNotFound {
+				response.State.RemoveResource(ctx)
+				return
+			}
+		}
+		response.Diagnostics.Append(AliasDestinationReadError(ctx, err)...)
+		return
+	}
+
+	destinations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, alias.Destinations)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if !destinations.Contains(ctx, state.Destination) {
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(CreateAliasDestinationID(state.LocalPart, state.DomainName, state.Destination))
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newAliasDestinationResourceIdentityModel(&state))...)
+}
+
+func newAliasDestinationResourceIdentityModel(state *AliasDestinationResourceModel) AliasDestinationResourceIdentityModel {
+	return AliasDestinationResourceIdentityModel{
+		LocalPart:   types.StringValue(state.LocalPart.ValueString()),
+		DomainName:  types.StringValue(state.DomainName.ValueString()),
+		Destination: types.StringValue(state.Destination.ValueString()),
+	}
+}
+
+func (r *AliasDestinationResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan AliasDestinationResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(CreateAliasDestinationID(plan.LocalPart, plan.DomainName, plan.Destination))
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+}
+
+func (r *AliasDestinationResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state AliasDestinationResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(state.DomainName.ValueString())
+
+	unlock := lockObject("alias", state.LocalPart.ValueString(), state.DomainName.ValueString())
+	defer unlock()
+
+	alias, err := r.MigaduClient.GetAlias(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
+	if err != nil {
+		var requestError *client.RequestError
+		if errors.As(err, &requestError) {
+			if requestError.StatusCode == http.StatusNotFound {
+				return
+			}
+		}
+		response.Diagnostics.Append(AliasDestinationDeleteError(ctx, err)...)
+		return
+	}
+
+	remainingDestinations := removeEmailAddress(ctx, alias.Destinations, state.Destination)
+	if len(remainingDestinations) == len(alias.Destinations) {
+		return
+	}
+	alias.Destinations = remainingDestinations
+
+	_, err = r.MigaduClient.UpdateAlias(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString(), alias)
+	if err != nil {
+		response.Diagnostics.Append(AliasDestinationDeleteError(ctx, err)...)
+		return
+	}
+}
+
+func (r *AliasDestinationResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID == "" && request.Identity != nil && !request.Identity.Raw.IsNull() {
+		var resourceIdentity AliasDestinationResourceIdentityModel
+		response.Diagnostics.Append(request.Identity.Get(ctx, &resourceIdentity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		localPart := resourceIdentity.LocalPart.ValueString()
+		domainName := resourceIdentity.DomainName.ValueString()
+		destination := resourceIdentity.Destination.ValueString()
+
+		if localPart == "" || domainName == "" || destination == "" {
+			response.Diagnostics.Append(AliasDestinationImportIdentityError())
+			return
+		}
+
+		tflog.Trace(ctx, "read import identity", map[string]interface{}{
+			"local_part":  localPart,
+			"domain_name": domainName,
+			"destination": destination,
+		})
+
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), CreateAliasDestinationIDString(localPart, domainName, destination))...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("destination"), destination)...)
+		return
+	}
+
+	idParts := strings.SplitN(request.ID, "/", 2)
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		response.Diagnostics.Append(AliasDestinationImportError(request.ID))
+		return
+	}
+
+	aliasPart := strings.Split(idParts[0], "@")
+	destination := idParts[1]
+
+	if len(aliasPart) != 2 || aliasPart[0] == "" || aliasPart[1] == "" {
+		response.Diagnostics.Append(AliasDestinationImportError(request.ID))
+		return
+	}
+
+	localPart := aliasPart[0]
+	domainName := aliasPart[1]
+
+	tflog.Trace(ctx, "parsed import ID", map[string]interface{}{
+		"local_part":  localPart,
+		"domain_name": domainName,
+		"destination": destination,
+	})
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("destination"), destination)...)
+}
@@ -0,0 +1,346 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestAliasDestinationResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	provider.NewAliasDestinationResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestAliasDestinationResource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		localPart   string
+		domainName  string
+		destination string
+		state       []model.Alias
+	}{
+		"add-destination": {
+			localPart:   "team",
+			domainName:  "example.com",
+			destination: "new@example.com",
+			state: []model.Alias{
+				{
+					LocalPart:    "team",
+					DomainName:   "example.com",
+					Address:      "team@example.com",
+					Destinations: []string{"existing@example.com"},
+				},
+			},
+		},
+		"existing-destination": {
+			localPart:   "team",
+			domainName:  "example.com",
+			destination: "existing@example.com",
+			state: []model.Alias{
+				{
+					LocalPart:    "team",
+					DomainName:   "example.com",
+					Address:      "team@example.com",
+					Destinations: []string{"existing@example.com"},
+				},
+			},
+		},
+		"idna": {
+			localPart:   "team",
+			domainName:  "hoß.de",
+			destination: "new@hoß.de",
+			state: []model.Alias{
+				{
+					LocalPart:    "team",
+					DomainName:   "xn--ho-hia.de",
+					Address:      "team@xn--ho-hia.de",
+					Destinations: []string{"existing@xn--ho-hia.de"},
+				},
+			},
+		},
+		"idna-punycode-destination": {
+			localPart:   "team",
+			domainName:  "hoß.de",
+			destination: "existing@hoß.de",
+			state: []model.Alias{
+				{
+					LocalPart:    "team",
+					DomainName:   "xn--ho-hia.de",
+					Address:      "team@xn--ho-hia.de",
+					Destinations: []string{"existing@xn--ho-hia.de"},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Aliases: testCase.state}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							resource "migadu_alias_destination" "test" {
+								local_part  = "%s"
+								domain_name = "%s"
+								destination = "%s"
+							}
+						`, testCase.localPart, testCase.domainName, testCase.destination),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_alias_destination.test", "id", fmt.Sprintf("%s@%s/%s", testCase.localPart, testCase.domainName, testCase.destination)),
+							resource.TestCheckResourceAttr("migadu_alias_destination.test", "local_part", testCase.localPart),
+							resource.TestCheckResourceAttr("migadu_alias_destination.test", "domain_name", testCase.domainName),
+							resource.TestCheckResourceAttr("migadu_alias_destination.test", "destination", testCase.destination),
+						),
+					},
+					{
+						ResourceName:      "migadu_alias_destination.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAliasDestinationResource_API_Success_Parallel(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{
+		Aliases: []model.Alias{
+			{
+				LocalPart:    "team",
+				DomainName:   "example.com",
+				Address:      "team@example.com",
+				Destinations: []string{"lead@example.com"},
+			},
+		},
+	}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_alias_destination" "test" {
+						count = 8
+
+						local_part  = "team"
+						domain_name = "example.com"
+						destination = "member-${count.index}@example.com"
+					}
+				`,
+			},
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_alias_destination" "test" {
+						count = 8
+
+						local_part  = "team"
+						domain_name = "example.com"
+						destination = "member-${count.index}@example.com"
+					}
+
+					data "migadu_alias" "team" {
+						local_part  = "team"
+						domain_name = "example.com"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_alias.team", "destinations.#", "9"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_alias_destination" "test" {
+						count = 2
+
+						local_part  = "team"
+						domain_name = "example.com"
+						destination = "member-${count.index}@example.com"
+					}
+				`,
+			},
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_alias_destination" "test" {
+						count = 2
+
+						local_part  = "team"
+						domain_name = "example.com"
+						destination = "member-${count.index}@example.com"
+					}
+
+					data "migadu_alias" "team" {
+						local_part  = "team"
+						domain_name = "example.com"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_alias.team", "destinations.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAliasDestinationResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{
+		Aliases: []model.Alias{
+			{
+				LocalPart:    "test",
+				DomainName:   "example.com",
+				Address:      "test@example.com",
+				Destinations: []string{"existing@example.com"},
+			},
+		},
+	}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_alias_destination" "test" {
+						local_part  = "test"
+						domain_name = "example.com"
+						destination = "someone@example.com"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("migadu_alias_destination.test", map[string]knownvalue.Check{
+						"local_part":  knownvalue.StringExact("test"),
+						"domain_name": knownvalue.StringExact("example.com"),
+						"destination": knownvalue.StringExact("someone@example.com"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "migadu_alias_destination.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAliasDestinationResource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetAlias: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetAlias: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							resource "migadu_alias_destination" "test" {
+								local_part  = "team"
+								domain_name = "example.com"
+								destination = "someone@example.com"
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAliasDestinationResource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"empty-domain-name": {
+			Configuration: `
+				domain_name = ""
+				local_part  = "team"
+				destination = "someone@example.com"
+			`,
+			ErrorRegex: "Attribute domain_name string length must be at least 1",
+		},
+		"empty-local-part": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = ""
+				destination = "someone@example.com"
+			`,
+			ErrorRegex: "Attribute local_part string length must be at least 1",
+		},
+		"invalid-destination": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "team"
+				destination = "someone"
+			`,
+			ErrorRegex: "An email must match the format 'local_part@domain'",
+		},
+		"missing-destination": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "team"
+			`,
+			ErrorRegex: `The argument "destination" is required, but no definition was found`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							resource "migadu_alias_destination" "test" {
+								%s
+							}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
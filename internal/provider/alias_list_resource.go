@@ -0,0 +1,114 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+var (
+	_ list.ListResource              = (*AliasListResource)(nil)
+	_ list.ListResourceWithConfigure = (*AliasListResource)(nil)
+)
+
+func NewAliasListResource() list.ListResource {
+	return &AliasListResource{}
+}
+
+type AliasListResource struct {
+	MigaduClient *client.MigaduClient
+}
+
+type AliasListResourceModel struct {
+	DomainName custom_types.DomainNameValue `tfsdk:"domain_name"`
+}
+
+func (r *AliasListResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_alias"
+}
+
+func (r *AliasListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, response *list.ListResourceSchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Lists all aliases of a domain.",
+		MarkdownDescription: "Lists all aliases of a domain.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain name of the aliases.",
+				MarkdownDescription: "The domain name of the aliases.",
+				Required:            true,
+				Optional:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *AliasListResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
+		r.MigaduClient = migaduClient
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (r *AliasListResource) List(ctx context.Context, request list.ListRequest, stream *list.ListResultsStream) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var config AliasListResourceModel
+	diags := request.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	aliases, err := r.MigaduClient.GetAliases(ctx, config.DomainName.ValueString())
+	if err != nil {
+		stream.Results = list.ListResultsStreamDiagnostics(AliasReadError(ctx, err))
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, alias := range aliases.Aliases {
+			result := request.NewListResult(ctx)
+			result.DisplayName = alias.Address
+
+			state := AliasResourceModel{
+				LocalPart:    types.StringValue(alias.LocalPart),
+				DomainName:   config.DomainName,
+				Destinations: custom_types.NewEmailAddressSetNull(),
+				Timeouts:     nullResourceTimeouts(),
+			}
+			result.Diagnostics.Append(refreshAliasResourceModel(ctx, &state, &alias)...)
+			result.Diagnostics.Append(result.Identity.Set(ctx, newAliasResourceIdentityModel(&state))...)
+			if request.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,242 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwlist "github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestAliasListResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwlist.ListResourceSchemaRequest{}
+	schemaResponse := &fwlist.ListResourceSchemaResponse{}
+
+	provider.NewAliasListResource().ListResourceConfigSchema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestAliasListResource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		config string
+		state  []model.Alias
+		want   int
+	}{
+		"empty": {
+			config: `
+				domain_name = "example.com"
+			`,
+			want: 0,
+		},
+		"single": {
+			config: `
+				domain_name = "example.com"
+			`,
+			state: []model.Alias{
+				{
+					LocalPart:    "test",
+					DomainName:   "example.com",
+					Address:      "test@example.com",
+					Destinations: []string{"someone@example.com"},
+				},
+			},
+			want: 1,
+		},
+		"multiple": {
+			config: `
+				domain_name = "example.com"
+			`,
+			state: []model.Alias{
+				{
+					LocalPart:    "test",
+					DomainName:   "example.com",
+					Address:      "test@example.com",
+					Destinations: []string{"someone@example.com"},
+				},
+				{
+					LocalPart:    "other",
+					DomainName:   "example.com",
+					Address:      "other@example.com",
+					Destinations: []string{"someone@example.com"},
+				},
+			},
+			want: 2,
+		},
+		"other-domain": {
+			config: `
+				domain_name = "example.com"
+			`,
+			state: []model.Alias{
+				{
+					LocalPart:    "test",
+					DomainName:   "example.com",
+					Address:      "test@example.com",
+					Destinations: []string{"someone@example.com"},
+				},
+				{
+					LocalPart:    "test",
+					DomainName:   "example.org",
+					Address:      "test@example.org",
+					Destinations: []string{"someone@example.org"},
+				},
+			},
+			want: 1,
+		},
+		"idna": {
+			config: `
+				domain_name = "hoß.de"
+			`,
+			state: []model.Alias{
+				{
+					LocalPart:    "test",
+					DomainName:   "xn--ho-hia.de",
+					Address:      "test@xn--ho-hia.de",
+					Destinations: []string{"someone@xn--ho-hia.de"},
+				},
+			},
+			want: 1,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Aliases: testCase.state}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_14_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL),
+					},
+					{
+						Query: true,
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							list "migadu_alias" "test" {
+								provider = migadu
+
+								config {
+									%s
+								}
+							}
+						`, testCase.config),
+						QueryResultChecks: []querycheck.QueryResultCheck{
+							querycheck.ExpectLength("migadu_alias.test", testCase.want),
+						},
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAliasListResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Aliases: []model.Alias{
+		{
+			LocalPart:    "test",
+			DomainName:   "example.com",
+			Address:      "test@example.com",
+			Destinations: []string{"someone@example.com"},
+		},
+	}}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL),
+			},
+			{
+				Query: true,
+				Config: providerConfig(server.URL) + `
+					list "migadu_alias" "test" {
+						provider = migadu
+
+						config {
+							domain_name = "example.com"
+						}
+					}
+				`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectIdentity("migadu_alias.test", map[string]knownvalue.Check{
+						"local_part":  knownvalue.StringExact("test"),
+						"domain_name": knownvalue.StringExact("example.com"),
+					}),
+				},
+			},
+		},
+	})
+}
+
+func TestAliasListResource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetAliases: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetAliases: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_14_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL),
+					},
+					{
+						Query: true,
+						Config: providerConfig(server.URL) + `
+							list "migadu_alias" "test" {
+								provider = migadu
+
+								config {
+									domain_name = "example.com"
+								}
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
@@ -9,10 +9,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -27,9 +30,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*AliasResource)(nil)
-	_ resource.ResourceWithConfigure   = (*AliasResource)(nil)
-	_ resource.ResourceWithImportState = (*AliasResource)(nil)
+	_ resource.Resource                 = (*AliasResource)(nil)
+	_ resource.ResourceWithConfigure    = (*AliasResource)(nil)
+	_ resource.ResourceWithImportState  = (*AliasResource)(nil)
+	_ resource.ResourceWithIdentity     = (*AliasResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*AliasResource)(nil)
 )
 
 func NewAliasResource() resource.Resource {
@@ -37,7 +42,9 @@ func NewAliasResource() resource.Resource {
 }
 
 type AliasResource struct {
-	MigaduClient *client.MigaduClient
+	MigaduClient  *client.MigaduClient
+	ReadCache     *ReadCache
+	AdoptExisting bool
 }
 
 type AliasResourceModel struct {
@@ -50,16 +57,24 @@ type AliasResourceModel struct {
 	Expirable        types.Bool                        `tfsdk:"expirable"`
 	ExpiresOn        types.String                      `tfsdk:"expires_on"`
 	RemoveUponExpiry types.Bool                        `tfsdk:"remove_upon_expiry"`
+	AdoptExisting    types.Bool                        `tfsdk:"adopt_existing"`
+	Timeouts         timeouts.Value                    `tfsdk:"timeouts"`
+}
+
+type AliasResourceIdentityModel struct {
+	LocalPart  types.String `tfsdk:"local_part"`
+	DomainName types.String `tfsdk:"domain_name"`
 }
 
 func (r *AliasResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_alias"
 }
 
-func (r *AliasResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+func (r *AliasResource) Schema(ctx context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		Description:         "Provides an email alias.",
 		MarkdownDescription: "Provides an email alias.",
+		Version:             1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description:         "Contains the value 'local_part@domain_name'.",
@@ -111,8 +126,8 @@ func (r *AliasResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				},
 			},
 			"destinations": schema.SetAttribute{
-				Description:         "Set of email addresses that act as destinations of the alias.",
-				MarkdownDescription: "Set of email addresses that act as destinations of the alias.",
+				Description:         "Set of email addresses that act as destinations of the alias. Do not use this attribute together with 'migadu_alias_destination' resources for the same alias.",
+				MarkdownDescription: "Set of email addresses that act as destinations of the alias. Do not use this attribute together with `migadu_alias_destination` resources for the same alias.",
 				Required:            true,
 				Optional:            false,
 				Computed:            false,
@@ -153,31 +168,81 @@ func (r *AliasResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:            true,
 				Computed:            true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description:         "Whether to adopt an existing alias with the same identifier instead of failing to create it, e.g. after a previous apply timed out after the alias was created. The adopted alias is updated to match this resource. Defaults to the 'adopt_existing' attribute of the provider.",
+				MarkdownDescription: "Whether to adopt an existing alias with the same identifier instead of failing to create it, e.g. after a previous apply timed out after the alias was created. The adopted alias is updated to match this resource. Defaults to the `adopt_existing` attribute of the provider.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *AliasResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the alias.",
+				RequiredForImport: true,
+			},
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the alias.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *AliasResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 0 includes the list based state with '*_punycode' attributes of releases before 2023.8.23
+		0: {
+			StateUpgrader: upgradeStateFromRawState,
 		},
 	}
 }
+
 func (r *AliasResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
 	if request.ProviderData == nil {
 		return
 	}
 
-	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
-		r.MigaduClient = migaduClient
+	if providerData, ok := request.ProviderData.(*MigaduProviderData); ok {
+		r.MigaduClient = providerData.Client
+		r.ReadCache = providerData.ReadCache
+		r.AdoptExisting = providerData.AdoptExisting
 	} else {
 		response.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *provider.MigaduProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 	}
 }
 
 func (r *AliasResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var plan AliasResourceModel
 	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var destinations []string
 	response.Diagnostics.Append(plan.Destinations.ElementsAs(ctx, &destinations, false)...)
 	if response.Diagnostics.HasError() {
@@ -194,8 +259,14 @@ func (r *AliasResource) Create(ctx context.Context, request resource.CreateReque
 	}
 
 	createdAlias, err := r.MigaduClient.CreateAlias(ctx, plan.DomainName.ValueString(), alias)
-	if err != nil {
-		response.Diagnostics.Append(AliasCreateError(err))
+	if err != nil && isConflictError(err) && adoptExisting(plan.AdoptExisting, r.AdoptExisting) {
+		createdAlias, diags = r.adopt(ctx, &plan, alias, err)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	} else if err != nil {
+		response.Diagnostics.Append(AliasCreateError(ctx, err)...)
 		return
 	}
 
@@ -207,16 +278,56 @@ func (r *AliasResource) Create(ctx context.Context, request resource.CreateReque
 	plan.RemoveUponExpiry = types.BoolValue(createdAlias.RemoveUponExpiry)
 
 	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newAliasResourceIdentityModel(&plan))...)
+}
+
+// adopt updates the existing alias of the given plan to match the given alias. The given error of the failed create
+// request is reported in case no alias with the same address exists, e.g. because a mailbox uses the address.
+func (r *AliasResource) adopt(ctx context.Context, plan *AliasResourceModel, alias *model.Alias, createErr error) (*model.Alias, diag.Diagnostics) {
+	createDiagnostics := AliasCreateError(ctx, createErr)
+
+	unlock := lockObject("alias", plan.LocalPart.ValueString(), plan.DomainName.ValueString())
+	defer unlock()
+
+	_, err := r.MigaduClient.GetAlias(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString())
+	if isNotFoundError(err) {
+		return nil, createDiagnostics
+	}
+	if err != nil {
+		return nil, AliasReadError(ctx, err)
+	}
+
+	tflog.Info(ctx, "Adopting existing alias", map[string]interface{}{
+		"local_part":  plan.LocalPart.ValueString(),
+		"domain_name": plan.DomainName.ValueString(),
+	})
+
+	updatedAlias, err := r.MigaduClient.UpdateAlias(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), alias)
+	if err != nil {
+		return nil, AliasUpdateError(ctx, err)
+	}
+	return updatedAlias, nil
 }
 
 func (r *AliasResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var state AliasResourceModel
 	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	alias, err := r.MigaduClient.GetAlias(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	alias, err := r.ReadCache.GetAlias(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
 	if err != nil {
 		var requestError *client.RequestError
 		if errors.As(err, &requestError) {
@@ -225,16 +336,30 @@ func (r *AliasResource) Read(ctx context.Context, request resource.ReadRequest,
 				return
 			}
 		}
-		response.Diagnostics.Append(AliasReadError(err))
+		response.Diagnostics.Append(AliasReadError(ctx, err)...)
 		return
 	}
 
-	receivedDestinations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, alias.Destinations)
-	response.Diagnostics.Append(diags...)
+	response.Diagnostics.Append(refreshAliasResourceModel(ctx, &state, alias)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newAliasResourceIdentityModel(&state))...)
+}
+
+// refreshAliasResourceModel updates the given state with the values of the given alias. Values that are semantically
+// equal to the current state are kept.
+func refreshAliasResourceModel(ctx context.Context, state *AliasResourceModel, alias *model.Alias) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	receivedDestinations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, alias.Destinations)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
 	if equal, _ := state.Destinations.SetSemanticEquals(ctx, receivedDestinations); !equal {
 		state.Destinations = receivedDestinations
 	}
@@ -246,16 +371,42 @@ func (r *AliasResource) Read(ctx context.Context, request resource.ReadRequest,
 	state.ExpiresOn = types.StringValue(alias.ExpiresOn)
 	state.RemoveUponExpiry = types.BoolValue(alias.RemoveUponExpiry)
 
-	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	return diagnostics
+}
+
+func newAliasResourceIdentityModel(state *AliasResourceModel) AliasResourceIdentityModel {
+	return AliasResourceIdentityModel{
+		LocalPart:  types.StringValue(state.LocalPart.ValueString()),
+		DomainName: types.StringValue(state.DomainName.ValueString()),
+	}
 }
 
 func (r *AliasResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var plan AliasResourceModel
 	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var state AliasResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	var destinations []string
 	response.Diagnostics.Append(plan.Destinations.ElementsAs(ctx, &destinations, false)...)
 	if response.Diagnostics.HasError() {
@@ -263,7 +414,6 @@ func (r *AliasResource) Update(ctx context.Context, request resource.UpdateReque
 	}
 
 	alias := &model.Alias{
-		LocalPart:        plan.LocalPart.ValueString(),
 		Destinations:     destinations,
 		IsInternal:       plan.IsInternal.ValueBool(),
 		Expirable:        plan.Expirable.ValueBool(),
@@ -271,9 +421,28 @@ func (r *AliasResource) Update(ctx context.Context, request resource.UpdateReque
 		RemoveUponExpiry: plan.RemoveUponExpiry.ValueBool(),
 	}
 
-	updatedAlias, err := r.MigaduClient.UpdateAlias(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), alias)
+	// only changed fields are sent in order to keep changes made outside of Terraform
+	fields := newRequestFields(alias)
+	fields.add(&alias.Destinations, plan.Destinations, state.Destinations)
+	fields.add(&alias.IsInternal, plan.IsInternal, state.IsInternal)
+	fields.add(&alias.Expirable, plan.Expirable, state.Expirable)
+	fields.add(&alias.ExpiresOn, plan.ExpiresOn, state.ExpiresOn)
+	fields.add(&alias.RemoveUponExpiry, plan.RemoveUponExpiry, state.RemoveUponExpiry)
+
+	// non-authoritative resources like migadu_alias_destination send the entire alias after reading it
+	unlock := lockObject("alias", plan.LocalPart.ValueString(), plan.DomainName.ValueString())
+	defer unlock()
+
+	var updatedAlias *model.Alias
+	var err error
+	if fields.empty() {
+		// nothing to send in case only attributes of this provider changed, e.g. the timeouts
+		updatedAlias, err = r.MigaduClient.GetAlias(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString())
+	} else {
+		updatedAlias, err = r.MigaduClient.UpdateAlias(fields.context(ctx), plan.DomainName.ValueString(), plan.LocalPart.ValueString(), alias)
+	}
 	if err != nil {
-		response.Diagnostics.Append(AliasUpdateError(err))
+		response.Diagnostics.Append(AliasUpdateError(ctx, err)...)
 		return
 	}
 
@@ -288,20 +457,59 @@ func (r *AliasResource) Update(ctx context.Context, request resource.UpdateReque
 }
 
 func (r *AliasResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var state AliasResourceModel
 	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.ReadCache.Invalidate(state.DomainName.ValueString())
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	_, err := r.MigaduClient.DeleteAlias(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(AliasDeleteError(err))
+		response.Diagnostics.Append(AliasDeleteError(ctx, err)...)
 		return
 	}
 }
 
 func (r *AliasResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID == "" && request.Identity != nil && !request.Identity.Raw.IsNull() {
+		var resourceIdentity AliasResourceIdentityModel
+		response.Diagnostics.Append(request.Identity.Get(ctx, &resourceIdentity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		localPart := resourceIdentity.LocalPart.ValueString()
+		domainName := resourceIdentity.DomainName.ValueString()
+
+		if localPart == "" || domainName == "" {
+			response.Diagnostics.Append(AliasImportIdentityError())
+			return
+		}
+
+		tflog.Trace(ctx, "read import identity", map[string]interface{}{
+			"local_part":  localPart,
+			"domain_name": domainName,
+		})
+
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), CreateAliasIDString(localPart, domainName))...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+		return
+	}
+
 	idParts := strings.Split(request.ID, "@")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
@@ -6,16 +6,24 @@
 package provider_test
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/metio/migadu-client.go/model"
 	"github.com/metio/migadu-client.go/simulator"
 	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestAliasResource_API_Success(t *testing.T) {
@@ -278,6 +286,264 @@ func TestAliasResource_API_Success(t *testing.T) {
 	}
 }
 
+func TestAliasResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_alias" "test" {
+						local_part   = "test"
+						domain_name  = "example.com"
+						destinations = ["someone@example.com"]
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("migadu_alias.test", map[string]knownvalue.Check{
+						"local_part":  knownvalue.StringExact("test"),
+						"domain_name": knownvalue.StringExact("example.com"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "migadu_alias.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestAliasResource_UpgradeState(t *testing.T) {
+	testCases := map[string]struct {
+		rawState string
+		want     []string
+	}{
+		"punycode-attributes": {
+			rawState: `{
+				"id": "test@hoß.de",
+				"local_part": "test",
+				"domain_name": "hoß.de",
+				"address": "test@xn--ho-hia.de",
+				"destinations": ["other@hoß.de", "someone@example.com"],
+				"destinations_punycode": ["other@xn--ho-hia.de", "someone@example.com"],
+				"is_internal": false,
+				"expirable": false,
+				"expires_on": "",
+				"remove_upon_expiry": false
+			}`,
+			want: []string{"other@hoß.de", "someone@example.com"},
+		},
+		"set-based": {
+			rawState: `{
+				"id": "test@example.com",
+				"local_part": "test",
+				"domain_name": "example.com",
+				"address": "test@example.com",
+				"destinations": ["someone@example.com"],
+				"is_internal": true,
+				"expirable": false,
+				"expires_on": "",
+				"remove_upon_expiry": false
+			}`,
+			want: []string{"someone@example.com"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			state := upgradeState(t, provider.NewAliasResource(), 0, testCase.rawState)
+
+			var upgraded provider.AliasResourceModel
+			diagnostics := state.Get(context.Background(), &upgraded)
+			if diagnostics.HasError() {
+				t.Fatalf("State diagnostics: %+v", diagnostics)
+			}
+
+			var destinations []string
+			upgraded.Destinations.ElementsAs(context.Background(), &destinations, false)
+			assert.ElementsMatch(t, testCase.want, destinations, "destinations")
+			assert.Equal(t, "test", upgraded.LocalPart.ValueString(), "local_part")
+		})
+	}
+}
+
+func TestAliasResource_API_Timeouts(t *testing.T) {
+	api := newLatencyInjector(simulator.MigaduAPI(t, &simulator.State{}))
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	config := func(value string) string {
+		return providerConfig(server.URL) + fmt.Sprintf(`
+			resource "migadu_alias" "test" {
+				local_part   = "test"
+				domain_name  = "example.com"
+				destinations = ["%s"]
+				timeouts {
+					create = "1s"
+					read   = "1s"
+					update = "1s"
+					delete = "1s"
+				}
+			}
+		`, value)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { api.Inject(http.MethodPost, 2*time.Second) },
+				Config:      config("someone@example.com"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig: func() { api.Inject("", 0) },
+				Config:    config("someone@example.com"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodGet, 2*time.Second) },
+				Config:      config("someone@example.com"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodPut, 2*time.Second) },
+				Config:      config("other@example.com"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodDelete, 2*time.Second) },
+				Config:      config("other@example.com"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig: func() { api.Inject("", 0) },
+				Config:    config("other@example.com"),
+			},
+		},
+	})
+}
+
+func TestAliasResource_API_PartialUpdate(t *testing.T) {
+	api := newBodyRecorder(simulator.MigaduAPI(t, &simulator.State{}), http.MethodPut)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_alias" "test" {
+						local_part   = "test"
+						domain_name  = "example.com"
+						destinations = ["someone@example.com"]
+					}
+				`,
+			},
+			{
+				PreConfig: func() { api.Fields() },
+				Config: providerConfig(server.URL) + `
+					resource "migadu_alias" "test" {
+						local_part   = "test"
+						domain_name  = "example.com"
+						destinations = ["other@example.com"]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_alias.test", "destinations.0", "other@example.com"),
+					func(_ *terraform.State) error {
+						if got, want := api.Fields(), [][]string{{"destinations"}}; !reflect.DeepEqual(got, want) {
+							return fmt.Errorf("expected update requests with fields %v, got %v", want, got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAliasResource_API_AdoptExisting(t *testing.T) {
+	testCases := map[string]struct {
+		provider string
+		resource string
+	}{
+		"resource": {
+			resource: "adopt_existing = true",
+		},
+		"provider": {
+			provider: "adopt_existing = true",
+		},
+		"resource-overrides-provider": {
+			provider: "adopt_existing = false",
+			resource: "adopt_existing = true",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(conflictOnCreate(simulator.MigaduAPI(t, &simulator.State{
+				Aliases: []model.Alias{
+					{
+						LocalPart:    "test",
+						DomainName:   "example.com",
+						Address:      "test@example.com",
+						Destinations: []string{"someone@example.com"},
+					},
+				},
+			})))
+			defer server.Close()
+
+			config := func(adopt bool) string {
+				providerSettings, resourceSettings := "", ""
+				if adopt {
+					providerSettings, resourceSettings = testCase.provider, testCase.resource
+				}
+				return fmt.Sprintf(`
+					provider "migadu" {
+						username = "username"
+						token    = "token"
+						endpoint = "%s"
+						%s
+					}
+
+					resource "migadu_alias" "test" {
+						local_part   = "test"
+						domain_name  = "example.com"
+						destinations = ["other@example.com"]
+						%s
+					}
+				`, server.URL, providerSettings, resourceSettings)
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      config(false),
+						ExpectError: regexp.MustCompile("Error Creating Alias: Conflict"),
+					},
+					{
+						Config: config(true),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_alias.test", "id", "test@example.com"),
+							resource.TestCheckResourceAttr("migadu_alias.test", "address", "test@example.com"),
+							resource.TestCheckResourceAttr("migadu_alias.test", "destinations.#", "1"),
+							resource.TestCheckResourceAttr("migadu_alias.test", "destinations.0", "other@example.com"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
 func TestAliasResource_API_Errors(t *testing.T) {
 	testCases := map[string]APIErrorTestCase{
 		"error-404": {
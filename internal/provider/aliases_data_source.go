@@ -167,6 +167,8 @@ func (d *AliasesDataSource) Configure(_ context.Context, request datasource.Conf
 }
 
 func (d *AliasesDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var data AliasesDataSourceModel
 	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
 	if response.Diagnostics.HasError() {
@@ -175,7 +177,7 @@ func (d *AliasesDataSource) Read(ctx context.Context, request datasource.ReadReq
 
 	aliases, err := d.MigaduClient.GetAliases(ctx, data.DomainName.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(AliasReadError(err))
+		response.Diagnostics.Append(AliasReadError(ctx, err)...)
 		return
 	}
 
@@ -0,0 +1,389 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// tokenCommandTimeout bounds the runtime of a 'token_command', e.g. a credential helper waiting for user input.
+const tokenCommandTimeout = time.Minute
+
+// ErrCredentialsProfileNotFound is returned when the shared credentials file does not contain the requested profile.
+var ErrCredentialsProfileNotFound = errors.New("profile not found")
+
+// TokenSource describes where to obtain the API token from. The first non-empty field wins.
+type TokenSource struct {
+	// Token is the API token itself.
+	Token string
+	// TokenFile is the path to a file containing the API token.
+	TokenFile string
+	// TokenCommand is a command whose standard output is the API token. It is run by 'sh -c' or 'cmd /C' on Windows.
+	TokenCommand string
+}
+
+func (s TokenSource) IsEmpty() bool {
+	return s.Token == "" && s.TokenFile == "" && s.TokenCommand == ""
+}
+
+// Resolve returns the API token of this source. Leading and trailing whitespace of files and command output is
+// removed.
+func (s TokenSource) Resolve(ctx context.Context) (string, error) {
+	switch {
+	case s.Token != "":
+		return s.Token, nil
+	case s.TokenFile != "":
+		return ReadTokenFile(s.TokenFile)
+	case s.TokenCommand != "":
+		return RunTokenCommand(ctx, s.TokenCommand)
+	}
+	return "", nil
+}
+
+func ReadTokenFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
+
+func RunTokenCommand(ctx context.Context, command string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) && len(bytes.TrimSpace(exitError.Stderr)) > 0 {
+			return "", fmt.Errorf("token command failed: %w: %s", err, bytes.TrimSpace(exitError.Stderr))
+		}
+		return "", fmt.Errorf("token command failed: %w", err)
+	}
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", errors.New("token command printed no token")
+	}
+	return token, nil
+}
+
+// CredentialsProfile contains the credentials of a named profile of the shared credentials file.
+type CredentialsProfile struct {
+	Username string
+	TokenSource
+}
+
+// DefaultCredentialsFile returns the path of the shared credentials file, '$XDG_CONFIG_HOME/migadu/credentials' or
+// '~/.config/migadu/credentials' if XDG_CONFIG_HOME is not set.
+func DefaultCredentialsFile() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "migadu", "credentials"), nil
+}
+
+// ReadCredentialsProfile reads the given profile from the credentials file at the given path. The file contains
+// sections named after their profile with 'key = value' lines, e.g.:
+//
+//	[default]
+//	username      = some-name@example.com
+//	token_command = pass show migadu
+//
+// Supported keys are 'username', 'token', 'token_file' and 'token_command'. Lines starting with '#' or ';' are comments.
+func ReadCredentialsProfile(path string, profile string) (*CredentialsProfile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var credentials *CredentialsProfile
+	current := ""
+	scanner := bufio.NewScanner(file)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = strings.TrimSpace(line[1 : len(line)-1])
+			if current == profile && credentials == nil {
+				credentials = &CredentialsProfile{}
+			}
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected 'key = value' or '[profile]'", path, number)
+		}
+		if current == "" {
+			return nil, fmt.Errorf("%s:%d: '%s' is not part of a profile", path, number, strings.TrimSpace(key))
+		}
+		if current != profile {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "username":
+			credentials.Username = value
+		case "token":
+			credentials.Token = value
+		case "token_file":
+			credentials.TokenFile = value
+		case "token_command":
+			credentials.TokenCommand = value
+		default:
+			return nil, fmt.Errorf("%s:%d: unknown key '%s'", path, number, strings.TrimSpace(key))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if credentials == nil {
+		return nil, fmt.Errorf("%w: '%s' in %s", ErrCredentialsProfileNotFound, profile, path)
+	}
+	return credentials, nil
+}
+
+// credentialsSource contains the credentials of a single source and where they were found.
+type credentialsSource struct {
+	origin      string
+	credentials CredentialsProfile
+	profile     bool
+}
+
+// resolveCredentials returns the username and token of the given provider configuration. Each of them is taken from the
+// first of the following sources that provides it:
+//
+//  1. the 'username', 'token', 'token_file' and 'token_command' attributes
+//  2. the profile named by the 'profile' attribute
+//  3. the MIGADU_USERNAME, MIGADU_TOKEN, MIGADU_TOKEN_FILE and MIGADU_TOKEN_COMMAND environment variables
+//  4. the profile named by the MIGADU_PROFILE environment variable
+//
+// Once a profile provides the username or the token, the other profile is ignored. The shared credentials file is only
+// read in case a profile is named.
+func resolveCredentials(ctx context.Context, config MigaduProviderModel) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	sources := []credentialsSource{
+		{
+			origin: "the provider configuration",
+			credentials: CredentialsProfile{
+				Username: config.Username.ValueString(),
+				TokenSource: TokenSource{
+					Token:        config.Token.ValueString(),
+					TokenFile:    config.TokenFile.ValueString(),
+					TokenCommand: config.TokenCommand.ValueString(),
+				},
+			},
+		},
+	}
+
+	if profile := config.Profile.ValueString(); profile != "" {
+		source, err := loadCredentialsProfile(profile)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("profile"),
+				"Invalid Migadu API Profile",
+				"The supplied profile cannot be read: "+err.Error(),
+			)
+		} else {
+			sources = append(sources, source)
+		}
+	}
+
+	sources = append(sources, credentialsSource{
+		origin: "the MIGADU_* environment variables",
+		credentials: CredentialsProfile{
+			Username: os.Getenv("MIGADU_USERNAME"),
+			TokenSource: TokenSource{
+				Token:        os.Getenv("MIGADU_TOKEN"),
+				TokenFile:    os.Getenv("MIGADU_TOKEN_FILE"),
+				TokenCommand: os.Getenv("MIGADU_TOKEN_COMMAND"),
+			},
+		},
+	})
+
+	if profile := os.Getenv("MIGADU_PROFILE"); profile != "" {
+		source, err := loadCredentialsProfile(profile)
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root("profile"),
+				"Invalid Migadu API Profile",
+				"The profile supplied by the MIGADU_PROFILE environment variable cannot be read: "+err.Error(),
+			)
+		} else {
+			sources = append(sources, source)
+		}
+	}
+
+	if diags.HasError() {
+		return "", "", diags
+	}
+
+	// a profile is never mixed with another profile, e.g. the username of one account and the token of another
+	var username string
+	var tokenSource *credentialsSource
+	usedProfile := false
+	for _, source := range sources {
+		if source.profile && usedProfile {
+			continue
+		}
+		if username == "" && source.credentials.Username != "" {
+			username = source.credentials.Username
+			usedProfile = usedProfile || source.profile
+		}
+		if tokenSource == nil && !source.credentials.TokenSource.IsEmpty() {
+			tokenSource = &source
+			usedProfile = usedProfile || source.profile
+		}
+	}
+	if tokenSource == nil {
+		return username, "", diags
+	}
+
+	tflog.Debug(ctx, "Reading Migadu API token", map[string]interface{}{
+		"origin": tokenSource.origin,
+	})
+	token, err := tokenSource.credentials.Resolve(ctx)
+	if err != nil {
+		attribute := path.Root("token")
+		switch {
+		case tokenSource.profile:
+			attribute = path.Root("profile")
+		case tokenSource.credentials.TokenFile != "":
+			attribute = path.Root("token_file")
+		case tokenSource.credentials.TokenCommand != "":
+			attribute = path.Root("token_command")
+		}
+		diags.AddAttributeError(
+			attribute,
+			"Invalid Migadu API Token",
+			fmt.Sprintf("The token of %s cannot be read: %s", tokenSource.origin, err),
+		)
+	}
+	return username, token, diags
+}
+
+func loadCredentialsProfile(profile string) (credentialsSource, error) {
+	file, err := DefaultCredentialsFile()
+	if err != nil {
+		return credentialsSource{}, err
+	}
+	credentials, err := ReadCredentialsProfile(file, profile)
+	if err != nil {
+		return credentialsSource{}, err
+	}
+	return credentialsSource{
+		origin:      fmt.Sprintf("profile '%s' of %s", profile, file),
+		credentials: *credentials,
+		profile:     true,
+	}, nil
+}
+
+// checkCredentials lists the domains of the account to verify that the given endpoint is the Migadu API and that it
+// accepts the given credentials.
+func checkCredentials(ctx context.Context, httpClient *http.Client, endpoint, username, token string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/")+"/domains", nil)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("endpoint"),
+			"Invalid Migadu API Endpoint",
+			"The supplied endpoint cannot be used to create a request: "+err.Error(),
+		)
+		return diags
+	}
+	request.SetBasicAuth(username, token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("endpoint"),
+			"Unreachable Migadu API Endpoint",
+			fmt.Sprintf("The provider cannot connect to the Migadu API at %s: %s", endpoint, err),
+		)
+		return diags
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode == http.StatusUnauthorized || response.StatusCode == http.StatusForbidden:
+		for _, attribute := range []string{"username", "token"} {
+			diags.AddAttributeError(
+				path.Root(attribute),
+				"Invalid Migadu API Credentials",
+				fmt.Sprintf("The Migadu API rejected the supplied username and token with status '%s'. ", response.Status)+
+					"Ensure that the username is the email address of an administrator of the account and that the token is an active API key with read access. "+
+					"Take a look at https://www.migadu.com/api/#api-keys for more information.",
+			)
+		}
+		return diags
+	case response.StatusCode >= http.StatusInternalServerError:
+		diags.AddError(
+			"Unavailable Migadu API",
+			fmt.Sprintf("The Migadu API at %s responded with status '%s' while validating the credentials. Try again later or disable validate_credentials.", endpoint, response.Status),
+		)
+		return diags
+	case response.StatusCode != http.StatusOK:
+		diags.AddAttributeError(
+			path.Root("endpoint"),
+			"Unexpected Migadu API Endpoint",
+			fmt.Sprintf("The endpoint %s responded with status '%s' to a request for the domains of the account. ", endpoint, response.Status)+
+				"Ensure that the endpoint points to the Migadu API, e.g. 'https://api.migadu.com/v1/'.",
+		)
+		return diags
+	}
+
+	var body struct {
+		Domains json.RawMessage `json:"domains"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&body); err != nil || body.Domains == nil {
+		diags.AddAttributeError(
+			path.Root("endpoint"),
+			"Unexpected Migadu API Endpoint",
+			fmt.Sprintf("The endpoint %s did not respond with the domains of the account. ", endpoint)+
+				"Ensure that the endpoint points to the Migadu API, e.g. 'https://api.migadu.com/v1/'.",
+		)
+	}
+	return diags
+}
@@ -0,0 +1,471 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	internal "github.com/metio/terraform-provider-migadu/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestReadCredentialsProfile(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		content string
+		profile string
+		want    *internal.CredentialsProfile
+		wantErr string
+	}{
+		"default": {
+			content: `
+				# shared credentials
+				[default]
+				username = some-name@example.com
+				token    = secret
+			`,
+			profile: "default",
+			want: &internal.CredentialsProfile{
+				Username:    "some-name@example.com",
+				TokenSource: internal.TokenSource{Token: "secret"},
+			},
+		},
+		"named": {
+			content: `
+				[default]
+				username = some-name@example.com
+				token    = secret
+
+				[work]
+				; the token is stored in a password manager
+				username      = other-name@example.com
+				token_command = pass show migadu = work
+				token_file    = /run/secrets/migadu
+			`,
+			profile: "work",
+			want: &internal.CredentialsProfile{
+				Username: "other-name@example.com",
+				TokenSource: internal.TokenSource{
+					TokenFile:    "/run/secrets/migadu",
+					TokenCommand: "pass show migadu = work",
+				},
+			},
+		},
+		"unknown-key-of-other-profile": {
+			content: `
+				[default]
+				username = some-name@example.com
+				[other]
+				password = secret
+			`,
+			profile: "default",
+			want: &internal.CredentialsProfile{
+				Username: "some-name@example.com",
+			},
+		},
+		"missing-profile": {
+			content: `
+				[default]
+				token = secret
+			`,
+			profile: "work",
+			wantErr: "profile not found: 'work'",
+		},
+		"unknown-key": {
+			content: `
+				[default]
+				password = secret
+			`,
+			profile: "default",
+			wantErr: ":3: unknown key 'password'",
+		},
+		"malformed-line": {
+			content: `
+				[default]
+				token
+			`,
+			profile: "default",
+			wantErr: ":3: expected 'key = value' or '[profile]'",
+		},
+		"key-without-profile": {
+			content: `
+				token = secret
+			`,
+			profile: "default",
+			wantErr: ":2: 'token' is not part of a profile",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			file := filepath.Join(t.TempDir(), "credentials")
+			if err := os.WriteFile(file, []byte(testCase.content), 0o600); err != nil {
+				t.Fatalf("os.WriteFile: %v", err)
+			}
+
+			got, err := internal.ReadCredentialsProfile(file, testCase.profile)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr, "ReadCredentialsProfile")
+				return
+			}
+			assert.NoError(t, err, "ReadCredentialsProfile")
+			assert.Equal(t, testCase.want, got, "ReadCredentialsProfile")
+		})
+	}
+}
+
+func TestTokenSource_Resolve(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("token commands use POSIX shell syntax")
+	}
+
+	directory := t.TempDir()
+	tokenFile := filepath.Join(directory, "token")
+	if err := os.WriteFile(tokenFile, []byte("  from-file\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+	emptyFile := filepath.Join(directory, "empty")
+	if err := os.WriteFile(emptyFile, []byte("\n"), 0o600); err != nil {
+		t.Fatalf("os.WriteFile: %v", err)
+	}
+
+	testCases := map[string]struct {
+		source  internal.TokenSource
+		want    string
+		wantErr string
+	}{
+		"empty": {
+			source: internal.TokenSource{},
+			want:   "",
+		},
+		"token": {
+			source: internal.TokenSource{Token: "from-token", TokenFile: tokenFile, TokenCommand: "echo from-command"},
+			want:   "from-token",
+		},
+		"token-file": {
+			source: internal.TokenSource{TokenFile: tokenFile, TokenCommand: "echo from-command"},
+			want:   "from-file",
+		},
+		"token-command": {
+			source: internal.TokenSource{TokenCommand: "echo from-command"},
+			want:   "from-command",
+		},
+		"missing-file": {
+			source:  internal.TokenSource{TokenFile: filepath.Join(directory, "missing")},
+			wantErr: "no such file or directory",
+		},
+		"empty-file": {
+			source:  internal.TokenSource{TokenFile: emptyFile},
+			wantErr: "is empty",
+		},
+		"failing-command": {
+			source:  internal.TokenSource{TokenCommand: "echo locked >&2; exit 3"},
+			wantErr: "token command failed: exit status 3: locked",
+		},
+		"silent-command": {
+			source:  internal.TokenSource{TokenCommand: "true"},
+			wantErr: "token command printed no token",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := testCase.source.Resolve(context.Background())
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr, "Resolve")
+				return
+			}
+			assert.NoError(t, err, "Resolve")
+			assert.Equal(t, testCase.want, got, "Resolve")
+		})
+	}
+}
+
+func TestDefaultCredentialsFile(t *testing.T) {
+	directory := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", directory)
+
+	got, err := internal.DefaultCredentialsFile()
+	assert.NoError(t, err, "DefaultCredentialsFile")
+	assert.Equal(t, filepath.Join(directory, "migadu", "credentials"), got, "DefaultCredentialsFile")
+}
+
+func TestMigaduProvider_Configure_Credentials(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("token commands use POSIX shell syntax")
+	}
+
+	// every source supplies a failing token command naming itself, so that the error reveals which source was used
+	failing := func(source string) string {
+		return "echo " + source + " >&2; exit 1"
+	}
+	credentials := `
+		[default]
+		username      = default@example.com
+		token_command = ` + failing("default-profile") + `
+
+		[config]
+		token_command = ` + failing("config-profile") + `
+
+		[env]
+		token_command = ` + failing("env-profile") + `
+	`
+
+	testCases := map[string]struct {
+		config        map[string]tftypes.Value
+		env           map[string]string
+		wantAttribute path.Path
+		wantDetail    string
+	}{
+		"config": {
+			config: map[string]tftypes.Value{
+				"token_command": tftypes.NewValue(tftypes.String, failing("config")),
+				"profile":       tftypes.NewValue(tftypes.String, "config"),
+			},
+			env: map[string]string{
+				"MIGADU_TOKEN_COMMAND": failing("env"),
+				"MIGADU_PROFILE":       "env",
+			},
+			wantAttribute: path.Root("token_command"),
+			wantDetail:    "The token of the provider configuration cannot be read: token command failed: exit status 1: config",
+		},
+		"config-profile": {
+			config: map[string]tftypes.Value{
+				"profile": tftypes.NewValue(tftypes.String, "config"),
+			},
+			env: map[string]string{
+				"MIGADU_TOKEN_COMMAND": failing("env"),
+				"MIGADU_PROFILE":       "env",
+			},
+			wantAttribute: path.Root("profile"),
+			wantDetail:    "cannot be read: token command failed: exit status 1: config-profile",
+		},
+		"env": {
+			env: map[string]string{
+				"MIGADU_TOKEN_COMMAND": failing("env"),
+				"MIGADU_PROFILE":       "env",
+			},
+			wantAttribute: path.Root("token_command"),
+			wantDetail:    "The token of the MIGADU_* environment variables cannot be read: token command failed: exit status 1: env",
+		},
+		"env-profile": {
+			env: map[string]string{
+				"MIGADU_PROFILE": "env",
+			},
+			wantAttribute: path.Root("profile"),
+			wantDetail:    "cannot be read: token command failed: exit status 1: env-profile",
+		},
+		"without-default-profile": {
+			env: map[string]string{
+				"MIGADU_USERNAME": "env@example.com",
+			},
+			wantAttribute: path.Root("token"),
+			wantDetail:    "missing or empty value for the Migadu API token",
+		},
+		"missing-profile": {
+			config: map[string]tftypes.Value{
+				"profile": tftypes.NewValue(tftypes.String, "missing"),
+			},
+			wantAttribute: path.Root("profile"),
+			wantDetail:    "The supplied profile cannot be read: profile not found: 'missing'",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			configHome := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(configHome, "migadu"), 0o700); err != nil {
+				t.Fatalf("os.MkdirAll: %v", err)
+			}
+			if err := os.WriteFile(filepath.Join(configHome, "migadu", "credentials"), []byte(credentials), 0o600); err != nil {
+				t.Fatalf("os.WriteFile: %v", err)
+			}
+			t.Setenv("XDG_CONFIG_HOME", configHome)
+			for _, variable := range []string{"MIGADU_USERNAME", "MIGADU_TOKEN", "MIGADU_TOKEN_FILE", "MIGADU_TOKEN_COMMAND", "MIGADU_PROFILE"} {
+				t.Setenv(variable, testCase.env[variable])
+			}
+
+			diagnostics := configureProvider(t, testCase.config)
+
+			if assert.Equal(t, 1, diagnostics.ErrorsCount(), "errors: %+v", diagnostics) {
+				got := diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+				assert.Equal(t, testCase.wantAttribute, got.Path(), "Path")
+				assert.Contains(t, got.Detail(), testCase.wantDetail, "Detail")
+			}
+		})
+	}
+}
+
+func TestMigaduProvider_Configure_Credentials_Mixed_Sources(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	for _, variable := range []string{"MIGADU_USERNAME", "MIGADU_TOKEN_FILE", "MIGADU_TOKEN_COMMAND", "MIGADU_PROFILE"} {
+		t.Setenv(variable, "")
+	}
+	t.Setenv("MIGADU_TOKEN", "env-token")
+
+	diagnostics := configureProvider(t, map[string]tftypes.Value{
+		"username": tftypes.NewValue(tftypes.String, "config@example.com"),
+	})
+
+	assert.False(t, diagnostics.HasError(), "errors: %+v", diagnostics)
+}
+
+func TestMigaduProvider_Configure_ValidateCredentials(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	migaduAPI := func(w http.ResponseWriter, r *http.Request) {
+		username, token, _ := r.BasicAuth()
+		if username != "username" || token != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/v1/domains" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"domains":[]}`))
+	}
+
+	testCases := map[string]struct {
+		handler   http.HandlerFunc
+		endpoint  func(serverURL string) string
+		token     string
+		disabled  bool
+		wantPaths []path.Path
+	}{
+		"valid": {
+			handler:   migaduAPI,
+			wantPaths: nil,
+		},
+		"invalid-token": {
+			handler:   migaduAPI,
+			token:     "wrong",
+			wantPaths: []path.Path{path.Root("username"), path.Root("token")},
+		},
+		"invalid-token-disabled": {
+			handler:   migaduAPI,
+			token:     "wrong",
+			disabled:  true,
+			wantPaths: nil,
+		},
+		"forbidden": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			},
+			wantPaths: []path.Path{path.Root("username"), path.Root("token")},
+		},
+		"wrong-path": {
+			handler: migaduAPI,
+			endpoint: func(serverURL string) string {
+				return serverURL + "/v2/"
+			},
+			wantPaths: []path.Path{path.Root("endpoint")},
+		},
+		"not-migadu-api": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte("<html><body>It works!</body></html>"))
+			},
+			wantPaths: []path.Path{path.Root("endpoint")},
+		},
+		"other-json": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"status":"ok"}`))
+			},
+			wantPaths: []path.Path{path.Root("endpoint")},
+		},
+		"unavailable": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			},
+			wantPaths: []path.Path{path.Empty()},
+		},
+		"unreachable": {
+			handler: migaduAPI,
+			endpoint: func(serverURL string) string {
+				server := httptest.NewServer(http.NotFoundHandler())
+				server.Close()
+				return server.URL + "/v1/"
+			},
+			wantPaths: []path.Path{path.Root("endpoint")},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(testCase.handler)
+			defer server.Close()
+
+			endpoint := server.URL + "/v1/"
+			if testCase.endpoint != nil {
+				endpoint = testCase.endpoint(server.URL)
+			}
+			token := "token"
+			if testCase.token != "" {
+				token = testCase.token
+			}
+
+			diagnostics := configureProvider(t, map[string]tftypes.Value{
+				"endpoint":             tftypes.NewValue(tftypes.String, endpoint),
+				"username":             tftypes.NewValue(tftypes.String, "username"),
+				"token":                tftypes.NewValue(tftypes.String, token),
+				"max_retries":          tftypes.NewValue(tftypes.Number, 0),
+				"validate_credentials": tftypes.NewValue(tftypes.Bool, !testCase.disabled),
+			})
+
+			var gotPaths []path.Path
+			for _, diagnostic := range diagnostics.Errors() {
+				if withPath, ok := diagnostic.(diag.DiagnosticWithPath); ok {
+					gotPaths = append(gotPaths, withPath.Path())
+				} else {
+					gotPaths = append(gotPaths, path.Empty())
+				}
+			}
+			assert.Equal(t, testCase.wantPaths, gotPaths, "errors: %+v", diagnostics)
+		})
+	}
+}
+
+// configureProvider applies the given provider configuration. Attributes missing from the given values
+// are null.
+func configureProvider(t *testing.T, values map[string]tftypes.Value) diag.Diagnostics {
+	t.Helper()
+
+	ctx := context.Background()
+	p := internal.New()
+	schemaResponse := &provider.SchemaResponse{}
+	p.Schema(ctx, provider.SchemaRequest{}, schemaResponse)
+
+	objectType := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := values[name]; ok {
+			attributes[name] = value
+		} else {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+	config := tfsdk.Config{
+		Schema: schemaResponse.Schema,
+		Raw:    tftypes.NewValue(objectType, attributes),
+	}
+
+	response := &provider.ConfigureResponse{}
+	p.Configure(ctx, provider.ConfigureRequest{Config: config}, response)
+	return response.Diagnostics
+}
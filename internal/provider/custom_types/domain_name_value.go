@@ -58,7 +58,7 @@ func (v DomainNameValue) StringSemanticEquals(_ context.Context, newValuable bas
 		return false, diags
 	}
 
-	priorDomain, err := normalizeDomain(v.StringValue.ValueString())
+	priorDomain, err := NormalizeDomain(v.StringValue.ValueString())
 	if err != nil {
 		diags.AddError(
 			"Semantic Equality Check Error",
@@ -70,7 +70,7 @@ func (v DomainNameValue) StringSemanticEquals(_ context.Context, newValuable bas
 		return false, diags
 	}
 
-	newDomain, err := normalizeDomain(newValue.ValueString())
+	newDomain, err := NormalizeDomain(newValue.ValueString())
 	if err != nil {
 		diags.AddError(
 			"Semantic Equality Check Error",
@@ -85,7 +85,9 @@ func (v DomainNameValue) StringSemanticEquals(_ context.Context, newValuable bas
 	return priorDomain == newDomain, diags
 }
 
-func normalizeDomain(domain string) (string, error) {
+// NormalizeDomain returns the lowercase ASCII representation of the given domain name, which is used to compare
+// domain names semantically.
+func NormalizeDomain(domain string) (string, error) {
 	normalized := domain
 	normalized = strings.TrimSpace(normalized)
 	normalized = strings.ToLower(normalized)
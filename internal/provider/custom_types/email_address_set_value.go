@@ -79,7 +79,7 @@ func (v EmailAddressSetValue) SetSemanticEquals(ctx context.Context, newValuable
 	}
 
 	for _, elem := range v.Elements() {
-		if !newValue.contains(ctx, elem) {
+		if !newValue.Contains(ctx, elem) {
 			return false, diags
 		}
 	}
@@ -87,7 +87,8 @@ func (v EmailAddressSetValue) SetSemanticEquals(ctx context.Context, newValuable
 	return true, diags
 }
 
-func (v EmailAddressSetValue) contains(ctx context.Context, other attr.Value) bool {
+// Contains reports whether the set contains an email address that is semantically equal to the given value.
+func (v EmailAddressSetValue) Contains(ctx context.Context, other attr.Value) bool {
 	if otherEmail, ok := other.(EmailAddressValue); ok {
 		for _, elem := range v.Elements() {
 			if email, ok := elem.(EmailAddressValue); ok {
@@ -58,7 +58,7 @@ func (v EmailAddressValue) StringSemanticEquals(_ context.Context, newValuable b
 		return false, diags
 	}
 
-	priorEmail, err := normalizeEmail(v.StringValue.ValueString())
+	priorEmail, err := NormalizeEmail(v.StringValue.ValueString())
 	if err != nil {
 		diags.AddError(
 			"Semantic Equality Check Error",
@@ -70,7 +70,7 @@ func (v EmailAddressValue) StringSemanticEquals(_ context.Context, newValuable b
 		return false, diags
 	}
 
-	newEmail, err := normalizeEmail(newValue.ValueString())
+	newEmail, err := NormalizeEmail(newValue.ValueString())
 	if err != nil {
 		diags.AddError(
 			"Semantic Equality Check Error",
@@ -85,7 +85,9 @@ func (v EmailAddressValue) StringSemanticEquals(_ context.Context, newValuable b
 	return priorEmail == newEmail, diags
 }
 
-func normalizeEmail(email string) (string, error) {
+// NormalizeEmail returns the lowercase ASCII representation of the given email address, which is used to compare
+// email addresses semantically.
+func NormalizeEmail(email string) (string, error) {
 	normalized := email
 	normalized = strings.TrimSpace(normalized)
 	normalized = strings.ToLower(normalized)
@@ -0,0 +1,77 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/metio/migadu-client.go/model"
+)
+
+const domainCheckStatusOK = "ok"
+
+// domainAPIAttributes maps API error fields to the attributes of the domain resource.
+var domainAPIAttributes = map[string]string{
+	"name":                  "name",
+	"description":           "description",
+	"state":                 "state",
+	"hosted_dns":            "hosted_dns",
+	"spam_aggressiveness":   "spam_aggressiveness",
+	"greylisting_enabled":   "greylisting_enabled",
+	"catchall_destinations": "catchall_destinations",
+}
+
+func DomainCreateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Creating Domain", err, domainAPIAttributes)
+}
+
+func DomainReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Domain", err, domainAPIAttributes)
+}
+
+func DomainUpdateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Updating Domain", err, domainAPIAttributes)
+}
+
+func DomainRecordsReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Domain DNS Records", err, nil)
+}
+
+func DomainDiagnosticsReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Domain Diagnostics", err, nil)
+}
+
+func DomainCheckError(domainName string, check model.DomainCheck) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		fmt.Sprintf("Domain Check Failed: %s", check.Type),
+		fmt.Sprintf("The '%s' check of domain '%s' reported status '%s'. "+
+			"Update your DNS records so that they match the expected value and wait for the changes to propagate.\n\n"+
+			"Expected: %s\nActual: %s\nMessage: %s", check.Type, domainName, check.Status, check.Expected, check.Actual, check.Message),
+	)
+}
+
+func DomainDeleteWarning() diag.Diagnostic {
+	return diag.NewWarningDiagnostic(
+		"Domain Not Deleted",
+		"The Migadu API does not support deleting domains. The domain was removed from the Terraform state, "+
+			"but still exists in your Migadu account. Remove it manually in the Migadu web interface if it is no longer needed.",
+	)
+}
+
+func DomainImportError(id string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Domain",
+		standardImportErrorDetail("domain_name", id),
+	)
+}
+
+func DomainImportIdentityError() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Domain",
+		standardImportIdentityErrorDetail("name"),
+	)
+}
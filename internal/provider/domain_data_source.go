@@ -0,0 +1,168 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+var (
+	_ datasource.DataSource              = (*DomainDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*DomainDataSource)(nil)
+)
+
+func NewDomainDataSource() datasource.DataSource {
+	return &DomainDataSource{}
+}
+
+type DomainDataSource struct {
+	MigaduClient *client.MigaduClient
+}
+
+type DomainDataSourceModel struct {
+	ID                   custom_types.DomainNameValue      `tfsdk:"id"`
+	Name                 custom_types.DomainNameValue      `tfsdk:"name"`
+	Description          types.String                      `tfsdk:"description"`
+	State                types.String                      `tfsdk:"state"`
+	HostedDNS            types.Bool                        `tfsdk:"hosted_dns"`
+	SpamAggressiveness   types.String                      `tfsdk:"spam_aggressiveness"`
+	GreylistingEnabled   types.Bool                        `tfsdk:"greylisting_enabled"`
+	CatchallDestinations custom_types.EmailAddressSetValue `tfsdk:"catchall_destinations"`
+}
+
+func (d *DomainDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_domain"
+}
+
+func (d *DomainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Get information about a domain.",
+		MarkdownDescription: "Get information about a domain.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Same value as the 'name' attribute.",
+				MarkdownDescription: "Same value as the `name` attribute.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				CustomType:          custom_types.DomainNameType{},
+			},
+			"name": schema.StringAttribute{
+				Description:         "The name of the domain.",
+				MarkdownDescription: "The name of the domain.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "The description of the domain.",
+				MarkdownDescription: "The description of the domain.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+			},
+			"state": schema.StringAttribute{
+				Description:         "The activation state of the domain.",
+				MarkdownDescription: "The activation state of the domain.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+			},
+			"hosted_dns": schema.BoolAttribute{
+				Description:         "Whether the DNS records of the domain are hosted by Migadu.",
+				MarkdownDescription: "Whether the DNS records of the domain are hosted by Migadu.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+			},
+			"spam_aggressiveness": schema.StringAttribute{
+				Description:         "The default spam aggressiveness of mailboxes in the domain.",
+				MarkdownDescription: "The default spam aggressiveness of mailboxes in the domain.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+			},
+			"greylisting_enabled": schema.BoolAttribute{
+				Description:         "Whether greylisting is enabled for the domain.",
+				MarkdownDescription: "Whether greylisting is enabled for the domain.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+			},
+			"catchall_destinations": schema.SetAttribute{
+				Description:         "The email addresses that receive emails sent to non-existing addresses of the domain.",
+				MarkdownDescription: "The email addresses that receive emails sent to non-existing addresses of the domain.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				CustomType: custom_types.EmailAddressSetType{
+					SetType: types.SetType{
+						ElemType: custom_types.EmailAddressType{},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DomainDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
+		d.MigaduClient = migaduClient
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (d *DomainDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var data DomainDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	domain, err := d.MigaduClient.GetDomain(ctx, data.Name.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(DomainReadError(ctx, err)...)
+		return
+	}
+
+	catchallDestinations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, domain.CatchallDestinations)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.Name
+	data.Description = types.StringValue(domain.Description)
+	data.State = types.StringValue(domain.State)
+	data.HostedDNS = types.BoolValue(domain.HostedDNS)
+	data.SpamAggressiveness = types.StringValue(domain.SpamAggressiveness)
+	data.GreylistingEnabled = types.BoolValue(domain.GreylistingEnabled)
+	data.CatchallDestinations = catchallDestinations
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,226 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestDomainDataSource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwdatasource.SchemaRequest{}
+	schemaResponse := &fwdatasource.SchemaResponse{}
+
+	provider.NewDomainDataSource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestDomainDataSource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		name  string
+		state []model.Domain
+		want  model.Domain
+	}{
+		"single": {
+			name: "example.com",
+			state: []model.Domain{
+				{
+					Name:               "example.com",
+					Description:        "Some Description",
+					State:              "active",
+					HostedDNS:          false,
+					SpamAggressiveness: "default",
+				},
+			},
+			want: model.Domain{
+				Name:               "example.com",
+				Description:        "Some Description",
+				State:              "active",
+				HostedDNS:          false,
+				SpamAggressiveness: "default",
+			},
+		},
+		"multiple": {
+			name: "example.com",
+			state: []model.Domain{
+				{
+					Name:               "example.com",
+					Description:        "Some Description",
+					State:              "active",
+					SpamAggressiveness: "default",
+				},
+				{
+					Name:               "example.org",
+					Description:        "Other Description",
+					State:              "pending",
+					SpamAggressiveness: "most_aggressive",
+				},
+			},
+			want: model.Domain{
+				Name:               "example.com",
+				Description:        "Some Description",
+				State:              "active",
+				SpamAggressiveness: "default",
+			},
+		},
+		"idna": {
+			name: "hoß.de",
+			state: []model.Domain{
+				{
+					Name:                 "xn--ho-hia.de",
+					Description:          "Some Description",
+					State:                "active",
+					HostedDNS:            true,
+					SpamAggressiveness:   "default",
+					CatchallDestinations: []string{"catchall@xn--ho-hia.de"},
+				},
+			},
+			want: model.Domain{
+				Name:                 "xn--ho-hia.de",
+				Description:          "Some Description",
+				State:                "active",
+				HostedDNS:            true,
+				SpamAggressiveness:   "default",
+				CatchallDestinations: []string{"catchall@xn--ho-hia.de"},
+			},
+		},
+		"idna-punycode": {
+			name: "xn--ho-hia.de",
+			state: []model.Domain{
+				{
+					Name:               "xn--ho-hia.de",
+					Description:        "Some Description",
+					State:              "active",
+					SpamAggressiveness: "default",
+				},
+			},
+			want: model.Domain{
+				Name:               "xn--ho-hia.de",
+				Description:        "Some Description",
+				State:              "active",
+				SpamAggressiveness: "default",
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Domains: testCase.state}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							data "migadu_domain" "test" {
+								name = "%s"
+							}
+						`, testCase.name),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.migadu_domain.test", "id", testCase.name),
+							resource.TestCheckResourceAttr("data.migadu_domain.test", "name", testCase.name),
+							resource.TestCheckResourceAttr("data.migadu_domain.test", "description", testCase.want.Description),
+							resource.TestCheckResourceAttr("data.migadu_domain.test", "state", testCase.want.State),
+							resource.TestCheckResourceAttr("data.migadu_domain.test", "hosted_dns", fmt.Sprintf("%v", testCase.want.HostedDNS)),
+							resource.TestCheckResourceAttr("data.migadu_domain.test", "spam_aggressiveness", testCase.want.SpamAggressiveness),
+							resource.TestCheckResourceAttr("data.migadu_domain.test", "greylisting_enabled", fmt.Sprintf("%v", testCase.want.GreylistingEnabled)),
+							resource.TestCheckResourceAttr("data.migadu_domain.test", "catchall_destinations.#", fmt.Sprintf("%v", len(testCase.want.CatchallDestinations))),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDomainDataSource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetDomain: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetDomain: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							data "migadu_domain" "test" {
+								name = "example.com"
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDomainDataSource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"empty-name": {
+			Configuration: `
+				name = ""
+			`,
+			ErrorRegex: "Attribute name string length must be at least 1",
+		},
+		"missing-name": {
+			Configuration: ``,
+			ErrorRegex:    `The argument "name" is required, but no definition was found`,
+		},
+		"invalid-name": {
+			Configuration: `
+				name = "*.example.com"
+			`,
+			ErrorRegex: "Domain names must be convertible to ASCII",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							data "migadu_domain" "test" {
+								%s
+							}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,196 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+var (
+	_ datasource.DataSource              = (*DomainDiagnosticsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*DomainDiagnosticsDataSource)(nil)
+)
+
+func NewDomainDiagnosticsDataSource() datasource.DataSource {
+	return &DomainDiagnosticsDataSource{}
+}
+
+type DomainDiagnosticsDataSource struct {
+	MigaduClient *client.MigaduClient
+}
+
+type DomainDiagnosticsDataSourceModel struct {
+	ID          custom_types.DomainNameValue `tfsdk:"id"`
+	DomainName  custom_types.DomainNameValue `tfsdk:"domain_name"`
+	FailOnError types.Bool                   `tfsdk:"fail_on_error"`
+	Healthy     types.Bool                   `tfsdk:"healthy"`
+	Checks      []DomainCheckModel           `tfsdk:"checks"`
+}
+
+type DomainCheckModel struct {
+	Type     types.String `tfsdk:"type"`
+	Status   types.String `tfsdk:"status"`
+	Expected types.String `tfsdk:"expected"`
+	Actual   types.String `tfsdk:"actual"`
+	Message  types.String `tfsdk:"message"`
+}
+
+func (d *DomainDiagnosticsDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_domain_diagnostics"
+}
+
+func (d *DomainDiagnosticsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Get the results of the activation checks of a domain.",
+		MarkdownDescription: "Get the results of the activation checks of a domain.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Same value as the 'domain_name' attribute.",
+				MarkdownDescription: "Same value as the `domain_name` attribute.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				CustomType:          custom_types.DomainNameType{},
+			},
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain to check.",
+				MarkdownDescription: "The domain to check.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"fail_on_error": schema.BoolAttribute{
+				Description:         "Whether failed checks should be reported as errors. Defaults to 'false'.",
+				MarkdownDescription: "Whether failed checks should be reported as errors. Defaults to `false`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+			},
+			"healthy": schema.BoolAttribute{
+				Description:         "Whether all checks passed.",
+				MarkdownDescription: "Whether all checks passed.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+			},
+			"checks": schema.ListNestedAttribute{
+				Description:         "The activation checks (MX, SPF, DKIM, DMARC, and verification) of the given 'domain_name'.",
+				MarkdownDescription: "The activation checks (MX, SPF, DKIM, DMARC, and verification) of the given `domain_name`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description:         "The type of the check, e.g. 'mx', 'spf', or 'dkim'.",
+							MarkdownDescription: "The type of the check, e.g. `mx`, `spf`, or `dkim`.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							Description:         "The status of the check. The value 'ok' denotes a passed check.",
+							MarkdownDescription: "The status of the check. The value `ok` denotes a passed check.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"expected": schema.StringAttribute{
+							Description:         "The value Migadu expects to find in DNS.",
+							MarkdownDescription: "The value Migadu expects to find in DNS.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"actual": schema.StringAttribute{
+							Description:         "The value Migadu found in DNS.",
+							MarkdownDescription: "The value Migadu found in DNS.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"message": schema.StringAttribute{
+							Description:         "A human readable explanation of the check result.",
+							MarkdownDescription: "A human readable explanation of the check result.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DomainDiagnosticsDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
+		d.MigaduClient = migaduClient
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (d *DomainDiagnosticsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var data DomainDiagnosticsDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	diagnostics, err := d.MigaduClient.GetDomainDiagnostics(ctx, data.DomainName.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(DomainDiagnosticsReadError(ctx, err)...)
+		return
+	}
+
+	healthy := true
+	for _, check := range diagnostics.Checks {
+		model := DomainCheckModel{
+			Type:     types.StringValue(check.Type),
+			Status:   types.StringValue(check.Status),
+			Expected: types.StringValue(check.Expected),
+			Actual:   types.StringValue(check.Actual),
+			Message:  types.StringValue(check.Message),
+		}
+		data.Checks = append(data.Checks, model)
+
+		if check.Status != domainCheckStatusOK {
+			healthy = false
+			if data.FailOnError.ValueBool() {
+				response.Diagnostics.Append(DomainCheckError(data.DomainName.ValueString(), check))
+			}
+		}
+	}
+
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.DomainName
+	data.Healthy = types.BoolValue(healthy)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,275 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestDomainDiagnosticsDataSource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwdatasource.SchemaRequest{}
+	schemaResponse := &fwdatasource.SchemaResponse{}
+
+	provider.NewDomainDiagnosticsDataSource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestDomainDiagnosticsDataSource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		domainName  string
+		failOnError bool
+		state       []model.DomainDiagnostics
+		want        model.DomainDiagnostics
+		healthy     bool
+	}{
+		"healthy": {
+			domainName: "example.com",
+			state: []model.DomainDiagnostics{
+				{
+					DomainName: "example.com",
+					Checks: []model.DomainCheck{
+						{Type: "mx", Status: "ok", Expected: "aspmx1.migadu.com", Actual: "aspmx1.migadu.com"},
+						{Type: "spf", Status: "ok", Expected: "v=spf1 include:spf.migadu.com -all", Actual: "v=spf1 include:spf.migadu.com -all"},
+					},
+				},
+			},
+			want: model.DomainDiagnostics{
+				Checks: []model.DomainCheck{
+					{Type: "mx", Status: "ok", Expected: "aspmx1.migadu.com", Actual: "aspmx1.migadu.com"},
+					{Type: "spf", Status: "ok", Expected: "v=spf1 include:spf.migadu.com -all", Actual: "v=spf1 include:spf.migadu.com -all"},
+				},
+			},
+			healthy: true,
+		},
+		"healthy-fail-on-error": {
+			domainName:  "example.com",
+			failOnError: true,
+			state: []model.DomainDiagnostics{
+				{
+					DomainName: "example.com",
+					Checks: []model.DomainCheck{
+						{Type: "mx", Status: "ok", Expected: "aspmx1.migadu.com", Actual: "aspmx1.migadu.com"},
+					},
+				},
+			},
+			want: model.DomainDiagnostics{
+				Checks: []model.DomainCheck{
+					{Type: "mx", Status: "ok", Expected: "aspmx1.migadu.com", Actual: "aspmx1.migadu.com"},
+				},
+			},
+			healthy: true,
+		},
+		"unhealthy": {
+			domainName: "example.com",
+			state: []model.DomainDiagnostics{
+				{
+					DomainName: "example.com",
+					Checks: []model.DomainCheck{
+						{Type: "mx", Status: "ok", Expected: "aspmx1.migadu.com", Actual: "aspmx1.migadu.com"},
+						{Type: "dkim", Status: "missing", Expected: "key1.example.com._domainkey.migadu.com", Actual: ""},
+					},
+				},
+			},
+			want: model.DomainDiagnostics{
+				Checks: []model.DomainCheck{
+					{Type: "mx", Status: "ok", Expected: "aspmx1.migadu.com", Actual: "aspmx1.migadu.com"},
+					{Type: "dkim", Status: "missing", Expected: "key1.example.com._domainkey.migadu.com", Actual: ""},
+				},
+			},
+			healthy: false,
+		},
+		"idna": {
+			domainName: "hoß.de",
+			state: []model.DomainDiagnostics{
+				{
+					DomainName: "xn--ho-hia.de",
+					Checks: []model.DomainCheck{
+						{Type: "mx", Status: "ok", Expected: "aspmx1.migadu.com", Actual: "aspmx1.migadu.com"},
+					},
+				},
+			},
+			want: model.DomainDiagnostics{
+				Checks: []model.DomainCheck{
+					{Type: "mx", Status: "ok", Expected: "aspmx1.migadu.com", Actual: "aspmx1.migadu.com"},
+				},
+			},
+			healthy: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{DomainDiagnostics: testCase.state}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							data "migadu_domain_diagnostics" "test" {
+								domain_name   = "%s"
+								fail_on_error = %t
+							}
+						`, testCase.domainName, testCase.failOnError),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.migadu_domain_diagnostics.test", "id", testCase.domainName),
+							resource.TestCheckResourceAttr("data.migadu_domain_diagnostics.test", "domain_name", testCase.domainName),
+							resource.TestCheckResourceAttr("data.migadu_domain_diagnostics.test", "healthy", fmt.Sprintf("%v", testCase.healthy)),
+							resource.TestCheckResourceAttr("data.migadu_domain_diagnostics.test", "checks.#", fmt.Sprintf("%v", len(testCase.want.Checks))),
+							resource.TestCheckResourceAttr("data.migadu_domain_diagnostics.test", "checks.0.type", testCase.want.Checks[0].Type),
+							resource.TestCheckResourceAttr("data.migadu_domain_diagnostics.test", "checks.0.status", testCase.want.Checks[0].Status),
+							resource.TestCheckResourceAttr("data.migadu_domain_diagnostics.test", "checks.0.expected", testCase.want.Checks[0].Expected),
+							resource.TestCheckResourceAttr("data.migadu_domain_diagnostics.test", "checks.0.actual", testCase.want.Checks[0].Actual),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDomainDiagnosticsDataSource_Check_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		state      []model.DomainDiagnostics
+		errorRegex string
+	}{
+		"missing-dkim": {
+			state: []model.DomainDiagnostics{
+				{
+					DomainName: "example.com",
+					Checks: []model.DomainCheck{
+						{Type: "mx", Status: "ok", Expected: "aspmx1.migadu.com", Actual: "aspmx1.migadu.com"},
+						{Type: "dkim", Status: "missing", Expected: "key1.example.com._domainkey.migadu.com", Actual: ""},
+					},
+				},
+			},
+			errorRegex: "Domain Check Failed: dkim",
+		},
+		"wrong-spf": {
+			state: []model.DomainDiagnostics{
+				{
+					DomainName: "example.com",
+					Checks: []model.DomainCheck{
+						{Type: "spf", Status: "mismatch", Expected: "v=spf1 include:spf.migadu.com -all", Actual: "v=spf1 -all"},
+					},
+				},
+			},
+			errorRegex: "Domain Check Failed: spf",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{DomainDiagnostics: testCase.state}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							data "migadu_domain_diagnostics" "test" {
+								domain_name   = "example.com"
+								fail_on_error = true
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.errorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDomainDiagnosticsDataSource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetDomainDiagnostics: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetDomainDiagnostics: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							data "migadu_domain_diagnostics" "test" {
+								domain_name = "example.com"
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDomainDiagnosticsDataSource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"empty-domain-name": {
+			Configuration: `
+				domain_name = ""
+			`,
+			ErrorRegex: "Attribute domain_name string length must be at least 1",
+		},
+		"missing-domain-name": {
+			Configuration: ``,
+			ErrorRegex:    `The argument "domain_name" is required, but no definition was found`,
+		},
+		"invalid-fail-on-error": {
+			Configuration: `
+				domain_name   = "example.com"
+				fail_on_error = "maybe"
+			`,
+			ErrorRegex: "Inappropriate value for attribute \"fail_on_error\"",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							data "migadu_domain_diagnostics" "test" {
+								%s
+							}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
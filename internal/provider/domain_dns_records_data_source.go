@@ -0,0 +1,167 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+var (
+	_ datasource.DataSource              = (*DomainDNSRecordsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*DomainDNSRecordsDataSource)(nil)
+)
+
+func NewDomainDNSRecordsDataSource() datasource.DataSource {
+	return &DomainDNSRecordsDataSource{}
+}
+
+type DomainDNSRecordsDataSource struct {
+	MigaduClient *client.MigaduClient
+}
+
+type DomainDNSRecordsDataSourceModel struct {
+	ID         custom_types.DomainNameValue `tfsdk:"id"`
+	DomainName custom_types.DomainNameValue `tfsdk:"domain_name"`
+	Records    []DomainDNSRecordModel       `tfsdk:"records"`
+}
+
+type DomainDNSRecordModel struct {
+	Type     types.String `tfsdk:"type"`
+	Name     types.String `tfsdk:"name"`
+	Value    types.String `tfsdk:"value"`
+	Priority types.Int64  `tfsdk:"priority"`
+	TTL      types.Int64  `tfsdk:"ttl"`
+}
+
+func (d *DomainDNSRecordsDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_domain_dns_records"
+}
+
+func (d *DomainDNSRecordsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Get the DNS records required by a domain, e.g. to manage them with a DNS provider.",
+		MarkdownDescription: "Get the DNS records required by a domain, e.g. to manage them with a DNS provider.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Same value as the 'domain_name' attribute.",
+				MarkdownDescription: "Same value as the `domain_name` attribute.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				CustomType:          custom_types.DomainNameType{},
+			},
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain to fetch DNS records of.",
+				MarkdownDescription: "The domain to fetch DNS records of.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"records": schema.ListNestedAttribute{
+				Description:         "The DNS records (MX, SPF, DKIM, DMARC, and verification) required for the given 'domain_name'.",
+				MarkdownDescription: "The DNS records (MX, SPF, DKIM, DMARC, and verification) required for the given `domain_name`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description:         "The type of the DNS record, e.g. 'MX', 'TXT', or 'CNAME'.",
+							MarkdownDescription: "The type of the DNS record, e.g. `MX`, `TXT`, or `CNAME`.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							Description:         "The name of the DNS record relative to the domain. The value '@' denotes the domain itself.",
+							MarkdownDescription: "The name of the DNS record relative to the domain. The value `@` denotes the domain itself.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							Description:         "The value of the DNS record.",
+							MarkdownDescription: "The value of the DNS record.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"priority": schema.Int64Attribute{
+							Description:         "The priority of the DNS record. Only set for 'MX' records.",
+							MarkdownDescription: "The priority of the DNS record. Only set for `MX` records.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							Description:         "The recommended time to live of the DNS record in seconds.",
+							MarkdownDescription: "The recommended time to live of the DNS record in seconds.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DomainDNSRecordsDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
+		d.MigaduClient = migaduClient
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (d *DomainDNSRecordsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var data DomainDNSRecordsDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	records, err := d.MigaduClient.GetDomainRecords(ctx, data.DomainName.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(DomainRecordsReadError(ctx, err)...)
+		return
+	}
+
+	for _, record := range records.Records {
+		model := DomainDNSRecordModel{
+			Type:     types.StringValue(record.Type),
+			Name:     types.StringValue(record.Name),
+			Value:    types.StringValue(record.Value),
+			Priority: types.Int64Value(int64(record.Priority)),
+			TTL:      types.Int64Value(int64(record.TTL)),
+		}
+		data.Records = append(data.Records, model)
+	}
+
+	data.ID = data.DomainName
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
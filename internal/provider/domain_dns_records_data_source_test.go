@@ -0,0 +1,219 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestDomainDNSRecordsDataSource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwdatasource.SchemaRequest{}
+	schemaResponse := &fwdatasource.SchemaResponse{}
+
+	provider.NewDomainDNSRecordsDataSource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestDomainDNSRecordsDataSource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		domainName string
+		state      []model.Domain
+	}{
+		"simple": {
+			domainName: "example.com",
+			state: []model.Domain{
+				{
+					Name:  "example.com",
+					State: "active",
+				},
+			},
+		},
+		"multiple": {
+			domainName: "example.org",
+			state: []model.Domain{
+				{
+					Name:  "example.com",
+					State: "active",
+				},
+				{
+					Name:  "example.org",
+					State: "pending",
+				},
+			},
+		},
+		"idna": {
+			domainName: "hoß.de",
+			state: []model.Domain{
+				{
+					Name:  "xn--ho-hia.de",
+					State: "active",
+				},
+			},
+		},
+		"idna-punycode": {
+			domainName: "xn--ho-hia.de",
+			state: []model.Domain{
+				{
+					Name:  "xn--ho-hia.de",
+					State: "active",
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Domains: testCase.state}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							data "migadu_domain_dns_records" "test" {
+								domain_name = "%s"
+							}
+						`, testCase.domainName),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.migadu_domain_dns_records.test", "id", testCase.domainName),
+							resource.TestCheckResourceAttr("data.migadu_domain_dns_records.test", "domain_name", testCase.domainName),
+							resource.TestCheckResourceAttrSet("data.migadu_domain_dns_records.test", "records.#"),
+							resource.TestCheckResourceAttrSet("data.migadu_domain_dns_records.test", "records.0.type"),
+							resource.TestCheckResourceAttrSet("data.migadu_domain_dns_records.test", "records.0.name"),
+							resource.TestCheckResourceAttrSet("data.migadu_domain_dns_records.test", "records.0.value"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDomainDNSRecordsDataSource_API_Success_IDNA(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{
+		Domains: []model.Domain{
+			{
+				Name:  "xn--ho-hia.de",
+				State: "active",
+			},
+		},
+	}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					data "migadu_domain_dns_records" "unicode" {
+						domain_name = "hoß.de"
+					}
+					data "migadu_domain_dns_records" "punycode" {
+						domain_name = "xn--ho-hia.de"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.migadu_domain_dns_records.unicode", "records.#", "data.migadu_domain_dns_records.punycode", "records.#"),
+					resource.TestCheckResourceAttrPair("data.migadu_domain_dns_records.unicode", "records.0.type", "data.migadu_domain_dns_records.punycode", "records.0.type"),
+					resource.TestCheckResourceAttrPair("data.migadu_domain_dns_records.unicode", "records.0.name", "data.migadu_domain_dns_records.punycode", "records.0.name"),
+					resource.TestCheckResourceAttrPair("data.migadu_domain_dns_records.unicode", "records.0.value", "data.migadu_domain_dns_records.punycode", "records.0.value"),
+					resource.TestCheckResourceAttrPair("data.migadu_domain_dns_records.unicode", "records.0.priority", "data.migadu_domain_dns_records.punycode", "records.0.priority"),
+					resource.TestCheckResourceAttrPair("data.migadu_domain_dns_records.unicode", "records.0.ttl", "data.migadu_domain_dns_records.punycode", "records.0.ttl"),
+				),
+			},
+		},
+	})
+}
+
+func TestDomainDNSRecordsDataSource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetDomainRecords: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetDomainRecords: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							data "migadu_domain_dns_records" "test" {
+								domain_name = "example.com"
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDomainDNSRecordsDataSource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"empty-domain-name": {
+			Configuration: `
+				domain_name = ""
+			`,
+			ErrorRegex: "Attribute domain_name string length must be at least 1",
+		},
+		"missing-domain-name": {
+			Configuration: ``,
+			ErrorRegex:    `The argument "domain_name" is required, but no definition was found`,
+		},
+		"invalid-domain-name": {
+			Configuration: `
+				domain_name = "*.example.com"
+			`,
+			ErrorRegex: "Domain names must be convertible to ASCII",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							data "migadu_domain_dns_records" "test" {
+								%s
+							}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,411 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+	"net/http"
+)
+
+var (
+	_ resource.Resource                = (*DomainResource)(nil)
+	_ resource.ResourceWithConfigure   = (*DomainResource)(nil)
+	_ resource.ResourceWithImportState = (*DomainResource)(nil)
+	_ resource.ResourceWithIdentity    = (*DomainResource)(nil)
+)
+
+func NewDomainResource() resource.Resource {
+	return &DomainResource{}
+}
+
+type DomainResource struct {
+	MigaduClient *client.MigaduClient
+}
+
+type DomainResourceModel struct {
+	ID                   custom_types.DomainNameValue      `tfsdk:"id"`
+	Name                 custom_types.DomainNameValue      `tfsdk:"name"`
+	Description          types.String                      `tfsdk:"description"`
+	State                types.String                      `tfsdk:"state"`
+	HostedDNS            types.Bool                        `tfsdk:"hosted_dns"`
+	SpamAggressiveness   types.String                      `tfsdk:"spam_aggressiveness"`
+	GreylistingEnabled   types.Bool                        `tfsdk:"greylisting_enabled"`
+	CatchallDestinations custom_types.EmailAddressSetValue `tfsdk:"catchall_destinations"`
+}
+
+type DomainResourceIdentityModel struct {
+	Name types.String `tfsdk:"name"`
+}
+
+func (r *DomainResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_domain"
+}
+
+func (r *DomainResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Provides a domain.",
+		MarkdownDescription: "Provides a domain.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Same value as the 'name' attribute.",
+				MarkdownDescription: "Same value as the `name` attribute.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				CustomType:          custom_types.DomainNameType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description:         "The name of the domain.",
+				MarkdownDescription: "The name of the domain.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Description:         "The description of the domain.",
+				MarkdownDescription: "The description of the domain.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Description:         "The activation state of the domain as reported by the Migadu API.",
+				MarkdownDescription: "The activation state of the domain as reported by the Migadu API.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+			},
+			"hosted_dns": schema.BoolAttribute{
+				Description:         "Whether the DNS records of this domain are hosted by Migadu.",
+				MarkdownDescription: "Whether the DNS records of this domain are hosted by Migadu.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"spam_aggressiveness": schema.StringAttribute{
+				Description:         "The default spam aggressiveness of mailboxes in this domain.",
+				MarkdownDescription: "The default spam aggressiveness of mailboxes in this domain.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"greylisting_enabled": schema.BoolAttribute{
+				Description:         "Whether greylisting is enabled for this domain.",
+				MarkdownDescription: "Whether greylisting is enabled for this domain.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"catchall_destinations": schema.SetAttribute{
+				Description:         "The email addresses that receive emails sent to non-existing addresses of this domain. Leave empty to reject such emails.",
+				MarkdownDescription: "The email addresses that receive emails sent to non-existing addresses of this domain. Leave empty to reject such emails.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+				CustomType: custom_types.EmailAddressSetType{
+					SetType: types.SetType{
+						ElemType: custom_types.EmailAddressType{},
+					},
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DomainResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				Description:       "The name of the domain.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *DomainResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if providerData, ok := request.ProviderData.(*MigaduProviderData); ok {
+		r.MigaduClient = providerData.Client
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.MigaduProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (r *DomainResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var plan DomainResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var catchallDestinations []string
+	if !plan.CatchallDestinations.IsUnknown() {
+		response.Diagnostics.Append(plan.CatchallDestinations.ElementsAs(ctx, &catchallDestinations, false)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	domain := &model.Domain{
+		Name:                 plan.Name.ValueString(),
+		Description:          plan.Description.ValueString(),
+		HostedDNS:            plan.HostedDNS.ValueBool(),
+		SpamAggressiveness:   plan.SpamAggressiveness.ValueString(),
+		GreylistingEnabled:   plan.GreylistingEnabled.ValueBool(),
+		CatchallDestinations: catchallDestinations,
+	}
+
+	// unconfigured attributes are unknown and left to the defaults of the API
+	fields := newRequestFields(domain)
+	fields.addAlways(&domain.Name)
+	fields.addKnown(&domain.Description, plan.Description)
+	fields.addKnown(&domain.HostedDNS, plan.HostedDNS)
+	fields.addKnown(&domain.SpamAggressiveness, plan.SpamAggressiveness)
+	fields.addKnown(&domain.GreylistingEnabled, plan.GreylistingEnabled)
+	fields.addKnown(&domain.CatchallDestinations, plan.CatchallDestinations)
+
+	createdDomain, err := r.MigaduClient.CreateDomain(fields.context(ctx), domain)
+	if err != nil {
+		response.Diagnostics.Append(DomainCreateError(ctx, err)...)
+		return
+	}
+
+	if plan.CatchallDestinations.IsUnknown() {
+		catchallDestinations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, createdDomain.CatchallDestinations)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		plan.CatchallDestinations = catchallDestinations
+	}
+
+	plan.ID = plan.Name
+	plan.Description = types.StringValue(createdDomain.Description)
+	plan.State = types.StringValue(createdDomain.State)
+	plan.HostedDNS = types.BoolValue(createdDomain.HostedDNS)
+	plan.SpamAggressiveness = types.StringValue(createdDomain.SpamAggressiveness)
+	plan.GreylistingEnabled = types.BoolValue(createdDomain.GreylistingEnabled)
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newDomainResourceIdentityModel(&plan))...)
+}
+
+func (r *DomainResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state DomainResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	domain, err := r.MigaduClient.GetDomain(ctx, state.Name.ValueString())
+	if err != nil {
+		var requestError *client.RequestError
+		if errors.As(err, &requestError) {
+			if requestError.StatusCode == http.StatusNotFound {
+				response.State.RemoveResource(ctx)
+				return
+			}
+		}
+		response.Diagnostics.Append(DomainReadError(ctx, err)...)
+		return
+	}
+
+	catchallDestinations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, domain.CatchallDestinations)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	catchallDestinationsEqual, diags := state.CatchallDestinations.SetSemanticEquals(ctx, catchallDestinations)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if !catchallDestinationsEqual {
+		state.CatchallDestinations = catchallDestinations
+	}
+
+	state.ID = state.Name
+	state.Description = types.StringValue(domain.Description)
+	state.State = types.StringValue(domain.State)
+	state.HostedDNS = types.BoolValue(domain.HostedDNS)
+	state.SpamAggressiveness = types.StringValue(domain.SpamAggressiveness)
+	state.GreylistingEnabled = types.BoolValue(domain.GreylistingEnabled)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newDomainResourceIdentityModel(&state))...)
+}
+
+func newDomainResourceIdentityModel(state *DomainResourceModel) DomainResourceIdentityModel {
+	return DomainResourceIdentityModel{
+		Name: types.StringValue(state.Name.ValueString()),
+	}
+}
+
+func (r *DomainResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var plan DomainResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var state DomainResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var catchallDestinations []string
+	if !plan.CatchallDestinations.IsUnknown() {
+		response.Diagnostics.Append(plan.CatchallDestinations.ElementsAs(ctx, &catchallDestinations, false)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	domain := &model.Domain{
+		Description:          plan.Description.ValueString(),
+		HostedDNS:            plan.HostedDNS.ValueBool(),
+		SpamAggressiveness:   plan.SpamAggressiveness.ValueString(),
+		GreylistingEnabled:   plan.GreylistingEnabled.ValueBool(),
+		CatchallDestinations: catchallDestinations,
+	}
+
+	// only changed fields are sent in order to keep changes made outside of Terraform
+	fields := newRequestFields(domain)
+	fields.add(&domain.Description, plan.Description, state.Description)
+	fields.add(&domain.HostedDNS, plan.HostedDNS, state.HostedDNS)
+	fields.add(&domain.SpamAggressiveness, plan.SpamAggressiveness, state.SpamAggressiveness)
+	fields.add(&domain.GreylistingEnabled, plan.GreylistingEnabled, state.GreylistingEnabled)
+	fields.add(&domain.CatchallDestinations, plan.CatchallDestinations, state.CatchallDestinations)
+
+	var updatedDomain *model.Domain
+	var err error
+	if fields.empty() {
+		// nothing to send in case the plan only differs in unknown values
+		updatedDomain, err = r.MigaduClient.GetDomain(ctx, plan.Name.ValueString())
+	} else {
+		updatedDomain, err = r.MigaduClient.UpdateDomain(fields.context(ctx), plan.Name.ValueString(), domain)
+	}
+	if err != nil {
+		response.Diagnostics.Append(DomainUpdateError(ctx, err)...)
+		return
+	}
+
+	if plan.CatchallDestinations.IsUnknown() {
+		catchallDestinations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, updatedDomain.CatchallDestinations)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		plan.CatchallDestinations = catchallDestinations
+	}
+
+	plan.ID = plan.Name
+	plan.Description = types.StringValue(updatedDomain.Description)
+	plan.State = types.StringValue(updatedDomain.State)
+	plan.HostedDNS = types.BoolValue(updatedDomain.HostedDNS)
+	plan.SpamAggressiveness = types.StringValue(updatedDomain.SpamAggressiveness)
+	plan.GreylistingEnabled = types.BoolValue(updatedDomain.GreylistingEnabled)
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+}
+
+func (r *DomainResource) Delete(_ context.Context, _ resource.DeleteRequest, response *resource.DeleteResponse) {
+	response.Diagnostics.Append(DomainDeleteWarning())
+}
+
+func (r *DomainResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID == "" && request.Identity != nil && !request.Identity.Raw.IsNull() {
+		var resourceIdentity DomainResourceIdentityModel
+		response.Diagnostics.Append(request.Identity.Get(ctx, &resourceIdentity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		name := resourceIdentity.Name.ValueString()
+
+		if name == "" {
+			response.Diagnostics.Append(DomainImportIdentityError())
+			return
+		}
+
+		tflog.Trace(ctx, "read import identity", map[string]interface{}{
+			"name": name,
+		})
+
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), name)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("name"), name)...)
+		return
+	}
+
+	if request.ID == "" {
+		response.Diagnostics.Append(DomainImportError(request.ID))
+		return
+	}
+
+	tflog.Trace(ctx, "parsed import ID", map[string]interface{}{
+		"name": request.ID,
+	})
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("name"), request.ID)...)
+}
@@ -0,0 +1,363 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestDomainResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	provider.NewDomainResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestDomainResource_API_Success(t *testing.T) {
+	testCases := map[string]ResourceTestCase[model.Domain]{
+		"change-description": {
+			Create: ResourceTestStep[model.Domain]{
+				Send: model.Domain{
+					Name:               "example.com",
+					Description:        "Some Description",
+					SpamAggressiveness: "default",
+				},
+				Want: model.Domain{
+					Name:               "example.com",
+					Description:        "Some Description",
+					SpamAggressiveness: "default",
+				},
+			},
+			Update: ResourceTestStep[model.Domain]{
+				Send: model.Domain{
+					Name:               "example.com",
+					Description:        "Different Description",
+					SpamAggressiveness: "default",
+				},
+				Want: model.Domain{
+					Name:               "example.com",
+					Description:        "Different Description",
+					SpamAggressiveness: "default",
+				},
+			},
+		},
+		"change-spam-aggressiveness": {
+			Create: ResourceTestStep[model.Domain]{
+				Send: model.Domain{
+					Name:               "example.com",
+					Description:        "Some Description",
+					SpamAggressiveness: "default",
+				},
+				Want: model.Domain{
+					Name:               "example.com",
+					Description:        "Some Description",
+					SpamAggressiveness: "default",
+				},
+			},
+			Update: ResourceTestStep[model.Domain]{
+				Send: model.Domain{
+					Name:               "example.com",
+					Description:        "Some Description",
+					SpamAggressiveness: "most_aggressive",
+				},
+				Want: model.Domain{
+					Name:               "example.com",
+					Description:        "Some Description",
+					SpamAggressiveness: "most_aggressive",
+				},
+			},
+		},
+		"idna": {
+			Create: ResourceTestStep[model.Domain]{
+				Send: model.Domain{
+					Name:               "hoß.de",
+					Description:        "Some Description",
+					SpamAggressiveness: "default",
+				},
+				Want: model.Domain{
+					Name:               "hoß.de",
+					Description:        "Some Description",
+					SpamAggressiveness: "default",
+				},
+			},
+			Update: ResourceTestStep[model.Domain]{
+				Send: model.Domain{
+					Name:               "hoß.de",
+					Description:        "Different Description",
+					SpamAggressiveness: "default",
+				},
+				Want: model.Domain{
+					Name:               "hoß.de",
+					Description:        "Different Description",
+					SpamAggressiveness: "default",
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							resource "migadu_domain" "test" {
+								name                = "%s"
+								description         = "%s"
+								spam_aggressiveness = "%s"
+							}
+						`, testCase.Create.Send.Name, testCase.Create.Send.Description, testCase.Create.Send.SpamAggressiveness),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_domain.test", "id", testCase.Create.Want.Name),
+							resource.TestCheckResourceAttr("migadu_domain.test", "name", testCase.Create.Want.Name),
+							resource.TestCheckResourceAttr("migadu_domain.test", "description", testCase.Create.Want.Description),
+							resource.TestCheckResourceAttr("migadu_domain.test", "spam_aggressiveness", testCase.Create.Want.SpamAggressiveness),
+							resource.TestCheckResourceAttr("migadu_domain.test", "hosted_dns", fmt.Sprintf("%v", testCase.Create.Want.HostedDNS)),
+							resource.TestCheckResourceAttr("migadu_domain.test", "greylisting_enabled", fmt.Sprintf("%v", testCase.Create.Want.GreylistingEnabled)),
+						),
+					},
+					{
+						ResourceName:            "migadu_domain.test",
+						ImportState:             true,
+						ImportStateVerify:       true,
+						ImportStateVerifyIgnore: testCase.ImportIgnore,
+					},
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							resource "migadu_domain" "test" {
+								name                = "%s"
+								description         = "%s"
+								spam_aggressiveness = "%s"
+							}
+						`, testCase.Update.Send.Name, testCase.Update.Send.Description, testCase.Update.Send.SpamAggressiveness),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_domain.test", "id", testCase.Update.Want.Name),
+							resource.TestCheckResourceAttr("migadu_domain.test", "name", testCase.Update.Want.Name),
+							resource.TestCheckResourceAttr("migadu_domain.test", "description", testCase.Update.Want.Description),
+							resource.TestCheckResourceAttr("migadu_domain.test", "spam_aggressiveness", testCase.Update.Want.SpamAggressiveness),
+							resource.TestCheckResourceAttr("migadu_domain.test", "hosted_dns", fmt.Sprintf("%v", testCase.Update.Want.HostedDNS)),
+							resource.TestCheckResourceAttr("migadu_domain.test", "greylisting_enabled", fmt.Sprintf("%v", testCase.Update.Want.GreylistingEnabled)),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDomainResource_API_Success_Catchall(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_domain" "test" {
+						name                  = "hoß.de"
+						catchall_destinations = ["catchall@hoß.de"]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_domain.test", "catchall_destinations.#", "1"),
+					resource.TestCheckResourceAttr("migadu_domain.test", "catchall_destinations.0", "catchall@hoß.de"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_domain" "test" {
+						name                  = "hoß.de"
+						catchall_destinations = ["catchall@xn--ho-hia.de"]
+					}
+				`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestDomainResource_API_KnownFields(t *testing.T) {
+	api := newBodyRecorder(simulator.MigaduAPI(t, &simulator.State{}), http.MethodPost)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_domain" "test" {
+						name       = "example.com"
+						hosted_dns = false
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_domain.test", "catchall_destinations.#", "0"),
+					func(_ *terraform.State) error {
+						if got, want := api.Fields(), [][]string{{"hosted_dns", "name"}}; !reflect.DeepEqual(got, want) {
+							return fmt.Errorf("expected create requests with fields %v, got %v", want, got)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_domain" "test" {
+						name       = "example.com"
+						hosted_dns = false
+					}
+				`,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestDomainResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_domain" "test" {
+						name = "example.com"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("migadu_domain.test", map[string]knownvalue.Check{
+						"name": knownvalue.StringExact("example.com"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "migadu_domain.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestDomainResource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-409": {
+			StatusCode: http.StatusConflict,
+			ErrorRegex: "CreateDomain: status: 409",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "CreateDomain: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							resource "migadu_domain" "test" {
+								name = "example.com"
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDomainResource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"empty-name": {
+			Configuration: `
+				name = ""
+			`,
+			ErrorRegex: "Attribute name string length must be at least 1",
+		},
+		"missing-name": {
+			Configuration: ``,
+			ErrorRegex:    `The argument "name" is required, but no definition was found`,
+		},
+		"invalid-name": {
+			Configuration: `
+				name = "*.example.com"
+			`,
+			ErrorRegex: "Domain names must be convertible to ASCII",
+		},
+		"wrong-email-format": {
+			Configuration: `
+				name                  = "example.com"
+				catchall_destinations = ["someone"]
+			`,
+			ErrorRegex: `An email must match the format 'local_part@domain'`,
+		},
+		"duplicate-emails": {
+			Configuration: `
+				name                  = "example.com"
+				catchall_destinations = ["someone@hoß.de", "someone@xn--ho-hia.de"]
+			`,
+			ErrorRegex: `This attribute contains duplicate values of: someone@xn--ho-hia.de`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							resource "migadu_domain" "test" {
+								%s
+							}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
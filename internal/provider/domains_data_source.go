@@ -0,0 +1,169 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+var (
+	_ datasource.DataSource              = (*DomainsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*DomainsDataSource)(nil)
+)
+
+func NewDomainsDataSource() datasource.DataSource {
+	return &DomainsDataSource{}
+}
+
+type DomainsDataSource struct {
+	MigaduClient *client.MigaduClient
+}
+
+type DomainsDataSourceModel struct {
+	Domains []DomainModel `tfsdk:"domains"`
+}
+
+type DomainModel struct {
+	Name                 custom_types.DomainNameValue      `tfsdk:"name"`
+	Description          types.String                      `tfsdk:"description"`
+	State                types.String                      `tfsdk:"state"`
+	HostedDNS            types.Bool                        `tfsdk:"hosted_dns"`
+	SpamAggressiveness   types.String                      `tfsdk:"spam_aggressiveness"`
+	GreylistingEnabled   types.Bool                        `tfsdk:"greylisting_enabled"`
+	CatchallDestinations custom_types.EmailAddressSetValue `tfsdk:"catchall_destinations"`
+}
+
+func (d *DomainsDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_domains"
+}
+
+func (d *DomainsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Get information about all domains of an account.",
+		MarkdownDescription: "Get information about all domains of an account.",
+		Attributes: map[string]schema.Attribute{
+			"domains": schema.ListNestedAttribute{
+				Description:         "The configured domains of the account.",
+				MarkdownDescription: "The configured domains of the account.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description:         "The name of the domain.",
+							MarkdownDescription: "The name of the domain.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+							CustomType:          custom_types.DomainNameType{},
+						},
+						"description": schema.StringAttribute{
+							Description:         "The description of the domain.",
+							MarkdownDescription: "The description of the domain.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"state": schema.StringAttribute{
+							Description:         "The activation state of the domain.",
+							MarkdownDescription: "The activation state of the domain.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"hosted_dns": schema.BoolAttribute{
+							Description:         "Whether the DNS records of the domain are hosted by Migadu.",
+							MarkdownDescription: "Whether the DNS records of the domain are hosted by Migadu.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"spam_aggressiveness": schema.StringAttribute{
+							Description:         "The default spam aggressiveness of mailboxes in the domain.",
+							MarkdownDescription: "The default spam aggressiveness of mailboxes in the domain.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"greylisting_enabled": schema.BoolAttribute{
+							Description:         "Whether greylisting is enabled for the domain.",
+							MarkdownDescription: "Whether greylisting is enabled for the domain.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"catchall_destinations": schema.SetAttribute{
+							Description:         "The email addresses that receive emails sent to non-existing addresses of the domain.",
+							MarkdownDescription: "The email addresses that receive emails sent to non-existing addresses of the domain.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+							CustomType: custom_types.EmailAddressSetType{
+								SetType: types.SetType{
+									ElemType: custom_types.EmailAddressType{},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DomainsDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
+		d.MigaduClient = migaduClient
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (d *DomainsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var data DomainsDataSourceModel
+
+	domains, err := d.MigaduClient.GetDomains(ctx)
+	if err != nil {
+		response.Diagnostics.Append(DomainReadError(ctx, err)...)
+		return
+	}
+
+	for _, domain := range domains.Domains {
+		catchallDestinations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, domain.CatchallDestinations)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		model := DomainModel{
+			Name:                 custom_types.NewDomainNameValue(domain.Name),
+			Description:          types.StringValue(domain.Description),
+			State:                types.StringValue(domain.State),
+			HostedDNS:            types.BoolValue(domain.HostedDNS),
+			SpamAggressiveness:   types.StringValue(domain.SpamAggressiveness),
+			GreylistingEnabled:   types.BoolValue(domain.GreylistingEnabled),
+			CatchallDestinations: catchallDestinations,
+		}
+
+		data.Domains = append(data.Domains, model)
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
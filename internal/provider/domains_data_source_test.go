@@ -0,0 +1,156 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestDomainsDataSource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwdatasource.SchemaRequest{}
+	schemaResponse := &fwdatasource.SchemaResponse{}
+
+	provider.NewDomainsDataSource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestDomainsDataSource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		state []model.Domain
+		want  model.Domains
+	}{
+		"empty": {
+			want: model.Domains{
+				Domains: []model.Domain{},
+			},
+		},
+		"single": {
+			state: []model.Domain{
+				{
+					Name:        "example.com",
+					Description: "Some Description",
+					State:       "active",
+				},
+			},
+			want: model.Domains{
+				Domains: []model.Domain{
+					{
+						Name:        "example.com",
+						Description: "Some Description",
+						State:       "active",
+					},
+				},
+			},
+		},
+		"multiple": {
+			state: []model.Domain{
+				{
+					Name:        "example.com",
+					Description: "Some Description",
+					State:       "active",
+				},
+				{
+					Name:        "xn--ho-hia.de",
+					Description: "Other Description",
+					State:       "pending",
+				},
+			},
+			want: model.Domains{
+				Domains: []model.Domain{
+					{
+						Name:        "example.com",
+						Description: "Some Description",
+						State:       "active",
+					},
+					{
+						Name:        "xn--ho-hia.de",
+						Description: "Other Description",
+						State:       "pending",
+					},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Domains: testCase.state}))
+			defer server.Close()
+
+			checks := []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr("data.migadu_domains.test", "domains.#", fmt.Sprintf("%v", len(testCase.want.Domains))),
+			}
+			for index, domain := range testCase.want.Domains {
+				checks = append(checks,
+					resource.TestCheckResourceAttr("data.migadu_domains.test", fmt.Sprintf("domains.%d.name", index), domain.Name),
+					resource.TestCheckResourceAttr("data.migadu_domains.test", fmt.Sprintf("domains.%d.description", index), domain.Description),
+					resource.TestCheckResourceAttr("data.migadu_domains.test", fmt.Sprintf("domains.%d.state", index), domain.State),
+				)
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							data "migadu_domains" "test" {}
+						`,
+						Check: resource.ComposeAggregateTestCheckFunc(checks...),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDomainsDataSource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-401": {
+			StatusCode: http.StatusUnauthorized,
+			ErrorRegex: "GetDomains: status: 401",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetDomains: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							data "migadu_domains" "test" {}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,63 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = (*EmailsEqualFunction)(nil)
+)
+
+func NewEmailsEqualFunction() function.Function {
+	return &EmailsEqualFunction{}
+}
+
+type EmailsEqualFunction struct{}
+
+func (f *EmailsEqualFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "emails_equal"
+}
+
+func (f *EmailsEqualFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Checks whether two email addresses are semantically equal.",
+		Description:         "Checks whether two email addresses are semantically equal, e.g. 'info@bücher.example' and 'INFO@xn--bcher-kva.example' are equal. Uses the same rules as all resources of this provider.",
+		MarkdownDescription: "Checks whether two email addresses are semantically equal, e.g. `info@bücher.example` and `INFO@xn--bcher-kva.example` are equal. Uses the same rules as all resources of this provider.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "first",
+				Description:         "The first email address to compare.",
+				MarkdownDescription: "The first email address to compare.",
+			},
+			function.StringParameter{
+				Name:                "second",
+				Description:         "The second email address to compare.",
+				MarkdownDescription: "The second email address to compare.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *EmailsEqualFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var first, second string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &first, &second))
+	if response.Error != nil {
+		return
+	}
+
+	normalizedFirst, firstErr := normalizeEmailArgument(0, first)
+	normalizedSecond, secondErr := normalizeEmailArgument(1, second)
+	response.Error = function.ConcatFuncErrors(firstErr, secondErr)
+	if response.Error != nil {
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, normalizedFirst == normalizedSecond))
+}
@@ -0,0 +1,132 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwfunction "github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"regexp"
+	"testing"
+)
+
+func TestEmailsEqualFunction_Definition(t *testing.T) {
+	ctx := context.Background()
+	definitionRequest := fwfunction.DefinitionRequest{}
+	definitionResponse := &fwfunction.DefinitionResponse{}
+
+	provider.NewEmailsEqualFunction().Definition(ctx, definitionRequest, definitionResponse)
+
+	if definitionResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition method diagnostics: %+v", definitionResponse.Diagnostics)
+	}
+
+	validateResponse := &fwfunction.DefinitionValidateResponse{}
+	definitionResponse.Definition.ValidateImplementation(ctx, fwfunction.DefinitionValidateRequest{FuncName: "emails_equal"}, validateResponse)
+	if validateResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition validation diagnostics: %+v", validateResponse.Diagnostics)
+	}
+}
+
+func TestEmailsEqualFunction_Success(t *testing.T) {
+	testCases := map[string]struct {
+		first  string
+		second string
+		want   string
+	}{
+		"same": {
+			first:  "info@example.com",
+			second: "info@example.com",
+			want:   "true",
+		},
+		"different-local-part": {
+			first:  "info@example.com",
+			second: "other@example.com",
+			want:   "false",
+		},
+		"different-domain": {
+			first:  "info@example.com",
+			second: "info@example.org",
+			want:   "false",
+		},
+		"uppercase": {
+			first:  "Info@Example.COM",
+			second: "info@example.com",
+			want:   "true",
+		},
+		"whitespace": {
+			first:  " info@example.com",
+			second: "info@example.com ",
+			want:   "true",
+		},
+		"idna": {
+			first:  "info@hoß.de",
+			second: "info@xn--ho-hia.de",
+			want:   "true",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::migadu::emails_equal("%s", "%s")
+							}
+						`, testCase.first, testCase.second),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckOutput("test", testCase.want),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestEmailsEqualFunction_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  string
+	}{
+		"invalid-first": {
+			input: `"info", "info@example.com"`,
+			want:  `An email must match the format 'local_part@domain'`,
+		},
+		"invalid-second": {
+			input: `"info@example.com", "info"`,
+			want:  `An email must match the format 'local_part@domain'`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::migadu::emails_equal(%s)
+							}
+						`, testCase.input),
+						ExpectError: regexp.MustCompile(testCase.want),
+					},
+				},
+			})
+		})
+	}
+}
@@ -6,7 +6,17 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/metio/migadu-client.go/client"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
 )
 
 func standardAPIErrorDetail(err error) string {
@@ -18,3 +28,175 @@ func standardAPIErrorDetail(err error) string {
 func standardImportErrorDetail(format string, id string) string {
 	return fmt.Sprintf("Expected import identifier with format: '%s' Got: '%s'", format, id)
 }
+
+func standardImportIdentityErrorDetail(attributes ...string) string {
+	return fmt.Sprintf("Expected import identity with non-empty attributes: '%s'", strings.Join(attributes, "', '"))
+}
+
+// APIErrorBody is the parsed body of a failed API response. Migadu reports errors either as a single message, e.g.
+// {"error": "..."}, or per field, e.g. {"errors": {"expires_on": ["is invalid"]}}.
+type APIErrorBody struct {
+	Message     string
+	FieldErrors map[string][]string
+}
+
+// ParseAPIErrorBody extracts the error message and field errors of the given response body. Bodies that are not JSON
+// objects result in an empty APIErrorBody.
+func ParseAPIErrorBody(body []byte) APIErrorBody {
+	var raw struct {
+		Error   json.RawMessage `json:"error"`
+		Message string          `json:"message"`
+		Errors  json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return APIErrorBody{}
+	}
+
+	parsed := APIErrorBody{Message: raw.Message}
+	var message string
+	if err := json.Unmarshal(raw.Error, &message); err == nil && message != "" {
+		parsed.Message = message
+	}
+
+	var fieldErrors map[string]json.RawMessage
+	var messages []string
+	if err := json.Unmarshal(raw.Errors, &fieldErrors); err == nil {
+		for field, value := range fieldErrors {
+			var fieldMessages []string
+			if err := json.Unmarshal(value, &fieldMessages); err != nil {
+				var fieldMessage string
+				if err := json.Unmarshal(value, &fieldMessage); err != nil {
+					continue
+				}
+				fieldMessages = []string{fieldMessage}
+			}
+			if parsed.FieldErrors == nil {
+				parsed.FieldErrors = map[string][]string{}
+			}
+			parsed.FieldErrors[field] = fieldMessages
+		}
+	} else if err := json.Unmarshal(raw.Errors, &messages); err == nil && parsed.Message == "" {
+		parsed.Message = strings.Join(messages, ", ")
+	}
+	return parsed
+}
+
+type apiErrorRecorderKey struct{}
+
+// apiErrorRecorder keeps the last failed API response of an operation. The Migadu client does not expose the bodies
+// of failed responses, so the transport records them in the context of the operation instead.
+type apiErrorRecorder struct {
+	mutex      sync.Mutex
+	statusCode int
+	body       []byte
+}
+
+// WithAPIErrorRecorder returns a context that records the last failed API response of the requests made with it.
+// APIErrorDiagnostics uses the recorded response to describe errors of the Migadu client.
+func WithAPIErrorRecorder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, apiErrorRecorderKey{}, &apiErrorRecorder{})
+}
+
+func (r *apiErrorRecorder) record(statusCode int, body []byte) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.statusCode = statusCode
+	r.body = body
+}
+
+// recordedAPIError returns the parsed body of the last failed API response recorded in the given context, if that
+// response has the given status code.
+func recordedAPIError(ctx context.Context, statusCode int) APIErrorBody {
+	recorder, ok := ctx.Value(apiErrorRecorderKey{}).(*apiErrorRecorder)
+	if !ok {
+		return APIErrorBody{}
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if recorder.statusCode != statusCode {
+		return APIErrorBody{}
+	}
+	return ParseAPIErrorBody(recorder.body)
+}
+
+// APIErrorDiagnostics describes the given error of an API call made with the given context. The summary names the
+// failed operation, e.g. 'Error Creating Mailbox'. Field errors reported by the API are attached to the attributes
+// the given map assigns to their field, all other errors are reported without an attribute.
+func APIErrorDiagnostics(ctx context.Context, summary string, err error, attributes map[string]string) diag.Diagnostics {
+	var requestError *client.RequestError
+	if !errors.As(err, &requestError) {
+		return diag.Diagnostics{diag.NewErrorDiagnostic(summary, standardAPIErrorDetail(err))}
+	}
+
+	title, hint := apiErrorStatus(requestError.StatusCode)
+	summary = summary + ": " + title
+	body := recordedAPIError(ctx, requestError.StatusCode)
+
+	var diagnostics diag.Diagnostics
+	var unmappedFieldErrors []string
+	fields := make([]string, 0, len(body.FieldErrors))
+	for field := range body.FieldErrors {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	for _, field := range fields {
+		messages := strings.Join(body.FieldErrors[field], ", ")
+		attribute, ok := attributes[field]
+		if !ok {
+			unmappedFieldErrors = append(unmappedFieldErrors, fmt.Sprintf("%s: %s", field, messages))
+			continue
+		}
+		diagnostics.AddAttributeError(
+			path.Root(attribute),
+			summary,
+			fmt.Sprintf("The Migadu API rejected the value of '%s': %s\n\nError: %s", attribute, messages, err),
+		)
+	}
+	if len(diagnostics) > 0 && len(unmappedFieldErrors) == 0 && body.Message == "" {
+		return diagnostics
+	}
+
+	detail := fmt.Sprintf("The Migadu API responded with status '%d %s'.", requestError.StatusCode, http.StatusText(requestError.StatusCode))
+	if body.Message != "" {
+		detail += "\n\nAPI message: " + body.Message
+	}
+	if len(unmappedFieldErrors) > 0 {
+		detail += "\n\nAPI field errors:\n  - " + strings.Join(unmappedFieldErrors, "\n  - ")
+	}
+	detail += "\n\n" + hint + "\n\nError: " + err.Error()
+	diagnostics.AddError(summary, detail)
+	return diagnostics
+}
+
+// apiErrorStatus returns a title and a remediation hint for failed API responses with the given status code.
+func apiErrorStatus(statusCode int) (string, string) {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return "Invalid Credentials",
+			"Ensure that the username is the email address of an administrator of the account and that the token is an active API key. " +
+				"Enable validate_credentials to detect invalid credentials while configuring the provider."
+	case statusCode == http.StatusForbidden:
+		return "Permission Denied",
+			"Ensure that the API key has read-write access and that the domain belongs to the account of the username."
+	case statusCode == http.StatusNotFound:
+		return "Not Found",
+			"The object or its parent, e.g. its domain or mailbox, does not exist. It may have been deleted outside of Terraform."
+	case statusCode == http.StatusConflict:
+		return "Conflict",
+			"An object with the same identifier already exists or the object is still in use. " +
+				"Import the existing object into Terraform or choose another identifier."
+	case statusCode == http.StatusUnprocessableEntity:
+		return "Invalid Values",
+			"The Migadu API rejected the values of the object. Correct the values mentioned above."
+	case statusCode == http.StatusTooManyRequests:
+		return "Rate Limit Exceeded",
+			"The account exceeded the rate limit of the Migadu API. " +
+				"Lower rate_limit or max_concurrent_requests, or raise max_retries, to stay within the limit."
+	case statusCode >= http.StatusInternalServerError:
+		return "Migadu API Unavailable",
+			"The Migadu API failed to process the request. This is usually temporary, try again later."
+	default:
+		return "Unexpected Response",
+			"Please contact the provider developer if you are unsure how to resolve the error."
+	}
+}
@@ -0,0 +1,274 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/metio/migadu-client.go/client"
+	internal "github.com/metio/terraform-provider-migadu/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseAPIErrorBody(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		body string
+		want internal.APIErrorBody
+	}{
+		"error": {
+			body: `{"error": "Address has already been taken"}`,
+			want: internal.APIErrorBody{Message: "Address has already been taken"},
+		},
+		"message": {
+			body: `{"message": "Not Found"}`,
+			want: internal.APIErrorBody{Message: "Not Found"},
+		},
+		"field-errors": {
+			body: `{"errors": {"expires_on": ["is invalid", "must be in the future"], "destinations": "can't be blank"}}`,
+			want: internal.APIErrorBody{FieldErrors: map[string][]string{
+				"expires_on":   {"is invalid", "must be in the future"},
+				"destinations": {"can't be blank"},
+			}},
+		},
+		"error-list": {
+			body: `{"errors": ["first", "second"]}`,
+			want: internal.APIErrorBody{Message: "first, second"},
+		},
+		"message-and-field-errors": {
+			body: `{"error": "Validation failed", "errors": {"name": ["is too long"]}}`,
+			want: internal.APIErrorBody{
+				Message:     "Validation failed",
+				FieldErrors: map[string][]string{"name": {"is too long"}},
+			},
+		},
+		"html": {
+			body: `<html><body>Bad Gateway</body></html>`,
+			want: internal.APIErrorBody{},
+		},
+		"empty": {
+			body: ``,
+			want: internal.APIErrorBody{},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, testCase.want, internal.ParseAPIErrorBody([]byte(testCase.body)), "ParseAPIErrorBody")
+		})
+	}
+}
+
+func TestAPIErrorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	type wantDiagnostic struct {
+		path    path.Path
+		summary string
+		detail  string
+	}
+	testCases := map[string]struct {
+		statusCode int
+		body       string
+		err        error
+		want       []wantDiagnostic
+	}{
+		"field-errors": {
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"errors": {"expires_on": ["is invalid"], "autorespond_active": ["must be a boolean"]}}`,
+			want: []wantDiagnostic{
+				{
+					path:    path.Root("auto_respond_active"),
+					summary: "Error Creating Mailbox: Invalid Values",
+					detail:  "The Migadu API rejected the value of 'auto_respond_active': must be a boolean",
+				},
+				{
+					path:    path.Root("expires_on"),
+					summary: "Error Creating Mailbox: Invalid Values",
+					detail:  "The Migadu API rejected the value of 'expires_on': is invalid",
+				},
+			},
+		},
+		"unknown-field-errors": {
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"errors": {"expires_on": ["is invalid"], "quota": ["is too large"]}}`,
+			want: []wantDiagnostic{
+				{
+					path:    path.Root("expires_on"),
+					summary: "Error Creating Mailbox: Invalid Values",
+					detail:  "The Migadu API rejected the value of 'expires_on': is invalid",
+				},
+				{
+					path:    path.Empty(),
+					summary: "Error Creating Mailbox: Invalid Values",
+					detail:  "API field errors:\n  - quota: is too large",
+				},
+			},
+		},
+		"unauthorized": {
+			statusCode: http.StatusUnauthorized,
+			body:       `{"error": "Invalid API key"}`,
+			want: []wantDiagnostic{
+				{
+					path:    path.Empty(),
+					summary: "Error Creating Mailbox: Invalid Credentials",
+					detail:  "API message: Invalid API key",
+				},
+			},
+		},
+		"forbidden": {
+			statusCode: http.StatusForbidden,
+			want: []wantDiagnostic{
+				{
+					path:    path.Empty(),
+					summary: "Error Creating Mailbox: Permission Denied",
+					detail:  "The Migadu API responded with status '403 Forbidden'.",
+				},
+			},
+		},
+		"not-found": {
+			statusCode: http.StatusNotFound,
+			want: []wantDiagnostic{
+				{
+					path:    path.Empty(),
+					summary: "Error Creating Mailbox: Not Found",
+					detail:  "It may have been deleted outside of Terraform.",
+				},
+			},
+		},
+		"conflict": {
+			statusCode: http.StatusConflict,
+			body:       `{"error": "Address has already been taken"}`,
+			want: []wantDiagnostic{
+				{
+					path:    path.Empty(),
+					summary: "Error Creating Mailbox: Conflict",
+					detail:  "API message: Address has already been taken",
+				},
+			},
+		},
+		"too-many-requests": {
+			statusCode: http.StatusTooManyRequests,
+			want: []wantDiagnostic{
+				{
+					path:    path.Empty(),
+					summary: "Error Creating Mailbox: Rate Limit Exceeded",
+					detail:  "Lower rate_limit or max_concurrent_requests, or raise max_retries",
+				},
+			},
+		},
+		"server-error": {
+			statusCode: http.StatusBadGateway,
+			body:       `<html><body>Bad Gateway</body></html>`,
+			want: []wantDiagnostic{
+				{
+					path:    path.Empty(),
+					summary: "Error Creating Mailbox: Migadu API Unavailable",
+					detail:  "The Migadu API responded with status '502 Bad Gateway'.",
+				},
+			},
+		},
+		"other-status": {
+			statusCode: http.StatusBadRequest,
+			body:       `{"error": "Malformed request"}`,
+			want: []wantDiagnostic{
+				{
+					path:    path.Empty(),
+					summary: "Error Creating Mailbox: Unexpected Response",
+					detail:  "API message: Malformed request",
+				},
+			},
+		},
+		"status-mismatch": {
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"errors": {"expires_on": ["is invalid"]}}`,
+			err:        &client.RequestError{StatusCode: http.StatusInternalServerError},
+			want: []wantDiagnostic{
+				{
+					path:    path.Empty(),
+					summary: "Error Creating Mailbox: Migadu API Unavailable",
+					detail:  "The Migadu API responded with status '500 Internal Server Error'.",
+				},
+			},
+		},
+		"network-error": {
+			statusCode: http.StatusUnprocessableEntity,
+			body:       `{"errors": {"expires_on": ["is invalid"]}}`,
+			err:        errors.New("dial tcp: connection refused"),
+			want: []wantDiagnostic{
+				{
+					path:    path.Empty(),
+					summary: "Error Creating Mailbox",
+					detail:  "Error: dial tcp: connection refused",
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(testCase.statusCode)
+				_, _ = w.Write([]byte(testCase.body))
+			}))
+			defer server.Close()
+
+			ctx := internal.WithAPIErrorRecorder(context.Background())
+			request, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, nil)
+			if err != nil {
+				t.Fatalf("http.NewRequestWithContext: %v", err)
+			}
+			httpClient := &http.Client{Transport: internal.NewAPIErrorTransport(http.DefaultTransport)}
+			response, err := httpClient.Do(request)
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+			body, err := io.ReadAll(response.Body)
+			assert.NoError(t, err, "ReadAll")
+			assert.NoError(t, response.Body.Close(), "Close")
+			assert.Equal(t, testCase.body, string(body), "body")
+
+			apiErr := testCase.err
+			if apiErr == nil {
+				apiErr = &client.RequestError{StatusCode: testCase.statusCode}
+			}
+			got := internal.MailboxCreateError(ctx, apiErr)
+
+			if assert.Len(t, got, len(testCase.want), "diagnostics: %+v", got) {
+				for index, want := range testCase.want {
+					gotPath := path.Empty()
+					if withPath, ok := got[index].(diag.DiagnosticWithPath); ok {
+						gotPath = withPath.Path()
+					}
+					assert.Equal(t, want.path, gotPath, "Path %d", index)
+					assert.Equal(t, want.summary, got[index].Summary(), "Summary %d", index)
+					assert.Contains(t, got[index].Detail(), want.detail, "Detail %d", index)
+					assert.Contains(t, got[index].Detail(), "Error: "+apiErr.Error(), "Detail %d", index)
+				}
+			}
+		})
+	}
+}
+
+func TestAPIErrorDiagnostics_WithoutRecorder(t *testing.T) {
+	t.Parallel()
+
+	got := internal.AliasUpdateError(context.Background(), &client.RequestError{StatusCode: http.StatusConflict})
+
+	if assert.Len(t, got, 1, "diagnostics") {
+		assert.Equal(t, "Error Updating Alias: Conflict", got[0].Summary(), "Summary")
+		assert.NotContains(t, got[0].Detail(), "API message", "Detail")
+	}
+}
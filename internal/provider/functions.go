@@ -0,0 +1,57 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+	"golang.org/x/net/idna"
+	"strings"
+)
+
+// splitEmailArgument splits the given email address into its local part and domain using the same rules as the
+// validation of email address attributes.
+func splitEmailArgument(position int64, email string) (string, string, *function.FuncError) {
+	parts := strings.Split(email, "@")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", EmailArgumentError(position, email)
+	}
+	return parts[0], parts[1], nil
+}
+
+// normalizeEmailArgument validates and normalizes the given email address.
+func normalizeEmailArgument(position int64, email string) (string, *function.FuncError) {
+	if _, _, funcErr := splitEmailArgument(position, email); funcErr != nil {
+		return "", funcErr
+	}
+	normalized, err := custom_types.NormalizeEmail(email)
+	if err != nil {
+		return "", function.NewArgumentFuncError(position, fmt.Sprintf("Could not convert email '%s' to ASCII: %s", email, err))
+	}
+	return normalized, nil
+}
+
+// normalizeDomainArgument validates and normalizes the given domain name using the same rules as domain name
+// attributes.
+func normalizeDomainArgument(position int64, domain string) (string, *function.FuncError) {
+	if _, err := idna.Lookup.ToASCII(domain); err != nil {
+		return "", DomainArgumentError(position, domain, err)
+	}
+	normalized, err := custom_types.NormalizeDomain(domain)
+	if err != nil {
+		return "", DomainArgumentError(position, domain, err)
+	}
+	return normalized, nil
+}
+
+func EmailArgumentError(position int64, email string) *function.FuncError {
+	return function.NewArgumentFuncError(position, fmt.Sprintf("An email must match the format 'local_part@domain', got: '%s'", email))
+}
+
+func DomainArgumentError(position int64, domain string, err error) *function.FuncError {
+	return function.NewArgumentFuncError(position, fmt.Sprintf("Could not convert domain name '%s': %s", domain, err))
+}
@@ -205,6 +205,8 @@ func (d *IdentitiesDataSource) Configure(_ context.Context, request datasource.C
 }
 
 func (d *IdentitiesDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var data IdentitiesDataSourceModel
 	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
 	if response.Diagnostics.HasError() {
@@ -213,7 +215,7 @@ func (d *IdentitiesDataSource) Read(ctx context.Context, request datasource.Read
 
 	identities, err := d.MigaduClient.GetIdentities(ctx, data.DomainName.ValueString(), data.LocalPart.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(IdentityReadError(err))
+		response.Diagnostics.Append(IdentityReadError(ctx, err)...)
 		return
 	}
 
@@ -6,12 +6,28 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
 )
 
+// identityAPIAttributes maps API error fields to the attributes of the identity resource.
+var identityAPIAttributes = map[string]string{
+	"local_part":             "identity",
+	"name":                   "name",
+	"may_send":               "may_send",
+	"may_receive":            "may_receive",
+	"may_access_imap":        "may_access_imap",
+	"may_access_managesieve": "may_access_manage_sieve",
+	"password":               "password",
+	"password_use":           "password_use",
+	"footer_active":          "footer_active",
+	"footer_plain_body":      "footer_plain_body",
+	"footer_html_body":       "footer_html_body",
+}
+
 func CreateIdentityID(localPart types.String, domainName custom_types.DomainNameValue, identity types.String) string {
 	return CreateIdentityIDString(localPart.ValueString(), domainName.ValueString(), identity.ValueString())
 }
@@ -20,32 +36,20 @@ func CreateIdentityIDString(localPart, domainName, identity string) string {
 	return fmt.Sprintf("%s@%s/%s", localPart, domainName, identity)
 }
 
-func IdentityCreateError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Creating Identity",
-		standardAPIErrorDetail(err),
-	)
+func IdentityCreateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Creating Identity", err, identityAPIAttributes)
 }
 
-func IdentityReadError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Reading Identity",
-		standardAPIErrorDetail(err),
-	)
+func IdentityReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Identity", err, identityAPIAttributes)
 }
 
-func IdentityUpdateError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Updating Identity",
-		standardAPIErrorDetail(err),
-	)
+func IdentityUpdateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Updating Identity", err, identityAPIAttributes)
 }
 
-func IdentityDeleteError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Deleting Identity",
-		standardAPIErrorDetail(err),
-	)
+func IdentityDeleteError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Deleting Identity", err, identityAPIAttributes)
 }
 
 func IdentityImportError(id string) diag.Diagnostic {
@@ -54,3 +58,10 @@ func IdentityImportError(id string) diag.Diagnostic {
 		standardImportErrorDetail("local_part@domain_name/identity", id),
 	)
 }
+
+func IdentityImportIdentityError() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Identity",
+		standardImportIdentityErrorDetail("local_part", "domain_name", "identity"),
+	)
+}
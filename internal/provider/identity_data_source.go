@@ -185,6 +185,8 @@ func (d *IdentityDataSource) Configure(_ context.Context, request datasource.Con
 }
 
 func (d *IdentityDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var data IdentityDataSourceModel
 	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
 	if response.Diagnostics.HasError() {
@@ -193,7 +195,7 @@ func (d *IdentityDataSource) Read(ctx context.Context, request datasource.ReadRe
 
 	identity, err := d.MigaduClient.GetIdentity(ctx, data.DomainName.ValueString(), data.LocalPart.ValueString(), data.Identity.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(IdentityReadError(err))
+		response.Diagnostics.Append(IdentityReadError(ctx, err)...)
 		return
 	}
 
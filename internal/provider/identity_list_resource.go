@@ -0,0 +1,139 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+var (
+	_ list.ListResource              = (*IdentityListResource)(nil)
+	_ list.ListResourceWithConfigure = (*IdentityListResource)(nil)
+)
+
+func NewIdentityListResource() list.ListResource {
+	return &IdentityListResource{}
+}
+
+type IdentityListResource struct {
+	MigaduClient *client.MigaduClient
+}
+
+type IdentityListResourceModel struct {
+	DomainName custom_types.DomainNameValue `tfsdk:"domain_name"`
+	LocalPart  types.String                 `tfsdk:"local_part"`
+}
+
+func (r *IdentityListResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_identity"
+}
+
+func (r *IdentityListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, response *list.ListResourceSchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Lists all identities of a domain or of a single mailbox.",
+		MarkdownDescription: "Lists all identities of a domain or of a single mailbox.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain name of the identities.",
+				MarkdownDescription: "The domain name of the identities.",
+				Required:            true,
+				Optional:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"local_part": schema.StringAttribute{
+				Description:         "The local part of the mailbox that owns the identities. Leave unset to list the identities of all mailboxes in the domain.",
+				MarkdownDescription: "The local part of the mailbox that owns the identities. Leave unset to list the identities of all mailboxes in the domain.",
+				Required:            false,
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *IdentityListResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
+		r.MigaduClient = migaduClient
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (r *IdentityListResource) List(ctx context.Context, request list.ListRequest, stream *list.ListResultsStream) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var config IdentityListResourceModel
+	diags := request.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	localParts := []string{config.LocalPart.ValueString()}
+	if config.LocalPart.IsNull() {
+		mailboxes, err := r.MigaduClient.GetMailboxes(ctx, config.DomainName.ValueString())
+		if err != nil {
+			stream.Results = list.ListResultsStreamDiagnostics(MailboxReadError(ctx, err))
+			return
+		}
+		localParts = localParts[:0]
+		for _, mailbox := range mailboxes.Mailboxes {
+			localParts = append(localParts, mailbox.LocalPart)
+		}
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, localPart := range localParts {
+			identities, err := r.MigaduClient.GetIdentities(ctx, config.DomainName.ValueString(), localPart)
+			if err != nil {
+				push(list.ListResult{Diagnostics: IdentityReadError(ctx, err)})
+				return
+			}
+
+			for _, identity := range identities.Identities {
+				result := request.NewListResult(ctx)
+				result.DisplayName = identity.Address
+
+				state := IdentityResourceModel{
+					LocalPart:  types.StringValue(localPart),
+					DomainName: config.DomainName,
+					Identity:   types.StringValue(identity.LocalPart),
+					Timeouts:   nullResourceTimeouts(),
+				}
+				refreshIdentityResourceModel(&state, &identity)
+				result.Diagnostics.Append(result.Identity.Set(ctx, newIdentityResourceIdentityModel(&state))...)
+				if request.IncludeResource {
+					result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+				}
+
+				if !push(result) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,269 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwlist "github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestIdentityListResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwlist.ListResourceSchemaRequest{}
+	schemaResponse := &fwlist.ListResourceSchemaResponse{}
+
+	provider.NewIdentityListResource().ListResourceConfigSchema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestIdentityListResource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		config     string
+		mailboxes  []model.Mailbox
+		identities []model.Identity
+		want       int
+	}{
+		"empty": {
+			config: `
+				domain_name = "example.com"
+				local_part  = "test"
+			`,
+			want: 0,
+		},
+		"single": {
+			config: `
+				domain_name = "example.com"
+				local_part  = "test"
+			`,
+			identities: []model.Identity{
+				{
+					LocalPart:  "other",
+					DomainName: "example.com",
+					Address:    "other@example.com",
+				},
+			},
+			want: 1,
+		},
+		"multiple": {
+			config: `
+				domain_name = "example.com"
+				local_part  = "test"
+			`,
+			identities: []model.Identity{
+				{
+					LocalPart:  "other",
+					DomainName: "example.com",
+					Address:    "other@example.com",
+				},
+				{
+					LocalPart:  "another",
+					DomainName: "example.com",
+					Address:    "another@example.com",
+				},
+			},
+			want: 2,
+		},
+		"all-mailboxes": {
+			config: `
+				domain_name = "example.com"
+			`,
+			mailboxes: []model.Mailbox{
+				{
+					LocalPart:  "test",
+					DomainName: "example.com",
+					Address:    "test@example.com",
+				},
+			},
+			identities: []model.Identity{
+				{
+					LocalPart:  "other",
+					DomainName: "example.com",
+					Address:    "other@example.com",
+				},
+			},
+			want: 1,
+		},
+		"all-mailboxes-empty": {
+			config: `
+				domain_name = "example.com"
+			`,
+			identities: []model.Identity{
+				{
+					LocalPart:  "other",
+					DomainName: "example.com",
+					Address:    "other@example.com",
+				},
+			},
+			want: 0,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Mailboxes: testCase.mailboxes, Identities: testCase.identities}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_14_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL),
+					},
+					{
+						Query: true,
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							list "migadu_identity" "test" {
+								provider = migadu
+
+								config {
+									%s
+								}
+							}
+						`, testCase.config),
+						QueryResultChecks: []querycheck.QueryResultCheck{
+							querycheck.ExpectLength("migadu_identity.test", testCase.want),
+						},
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestIdentityListResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Identities: []model.Identity{
+		{
+			LocalPart:  "other",
+			DomainName: "example.com",
+			Address:    "other@example.com",
+		},
+	}}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL),
+			},
+			{
+				Query: true,
+				Config: providerConfig(server.URL) + `
+					list "migadu_identity" "test" {
+						provider = migadu
+
+						config {
+							domain_name = "example.com"
+							local_part  = "test"
+						}
+					}
+				`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectIdentity("migadu_identity.test", map[string]knownvalue.Check{
+						"local_part":  knownvalue.StringExact("test"),
+						"domain_name": knownvalue.StringExact("example.com"),
+						"identity":    knownvalue.StringExact("other"),
+					}),
+				},
+			},
+		},
+	})
+}
+
+func TestIdentityListResource_API_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		config     string
+		statusCode int
+		errorRegex string
+	}{
+		"mailboxes-error-404": {
+			config: `
+				domain_name = "example.com"
+			`,
+			statusCode: http.StatusNotFound,
+			errorRegex: "GetMailboxes: status: 404",
+		},
+		"mailboxes-error-500": {
+			config: `
+				domain_name = "example.com"
+			`,
+			statusCode: http.StatusInternalServerError,
+			errorRegex: "GetMailboxes: status: 500",
+		},
+		"identities-error-404": {
+			config: `
+				domain_name = "example.com"
+				local_part  = "test"
+			`,
+			statusCode: http.StatusNotFound,
+			errorRegex: "GetIdentities: status: 404",
+		},
+		"identities-error-500": {
+			config: `
+				domain_name = "example.com"
+				local_part  = "test"
+			`,
+			statusCode: http.StatusInternalServerError,
+			errorRegex: "GetIdentities: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.statusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_14_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL),
+					},
+					{
+						Query: true,
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							list "migadu_identity" "test" {
+								provider = migadu
+
+								config {
+									%s
+								}
+							}
+						`, testCase.config),
+						ExpectError: regexp.MustCompile(testCase.errorRegex),
+					},
+				},
+			})
+		})
+	}
+}
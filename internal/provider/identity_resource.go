@@ -6,12 +6,17 @@
 package provider
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
@@ -28,9 +33,11 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*IdentityResource)(nil)
-	_ resource.ResourceWithConfigure   = (*IdentityResource)(nil)
-	_ resource.ResourceWithImportState = (*IdentityResource)(nil)
+	_ resource.Resource                 = (*IdentityResource)(nil)
+	_ resource.ResourceWithConfigure    = (*IdentityResource)(nil)
+	_ resource.ResourceWithImportState  = (*IdentityResource)(nil)
+	_ resource.ResourceWithIdentity     = (*IdentityResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*IdentityResource)(nil)
 )
 
 func NewIdentityResource() resource.Resource {
@@ -38,7 +45,8 @@ func NewIdentityResource() resource.Resource {
 }
 
 type IdentityResource struct {
-	MigaduClient *client.MigaduClient
+	MigaduClient  *client.MigaduClient
+	AdoptExisting bool
 }
 
 type IdentityResourceModel struct {
@@ -54,20 +62,31 @@ type IdentityResourceModel struct {
 	MayAccessPop3        types.Bool                     `tfsdk:"may_access_pop3"`
 	MayAccessManageSieve types.Bool                     `tfsdk:"may_access_manage_sieve"`
 	Password             types.String                   `tfsdk:"password"`
+	PasswordWO           types.String                   `tfsdk:"password_wo"`
+	PasswordWOVersion    types.Int64                    `tfsdk:"password_wo_version"`
 	PasswordUse          types.String                   `tfsdk:"password_use"`
 	FooterActive         types.Bool                     `tfsdk:"footer_active"`
 	FooterPlainBody      types.String                   `tfsdk:"footer_plain_body"`
 	FooterHtmlBody       types.String                   `tfsdk:"footer_html_body"`
+	AdoptExisting        types.Bool                     `tfsdk:"adopt_existing"`
+	Timeouts             timeouts.Value                 `tfsdk:"timeouts"`
+}
+
+type IdentityResourceIdentityModel struct {
+	LocalPart  types.String `tfsdk:"local_part"`
+	DomainName types.String `tfsdk:"domain_name"`
+	Identity   types.String `tfsdk:"identity"`
 }
 
 func (r *IdentityResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_identity"
 }
 
-func (r *IdentityResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+func (r *IdentityResource) Schema(ctx context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		Description:         "Provides an identity to an existing mailbox.",
 		MarkdownDescription: "Provides an identity to an existing mailbox.",
+		Version:             1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description:         "Contains the value 'local_part@domain_name/identity'.",
@@ -186,6 +205,29 @@ func (r *IdentityResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"password_wo": schema.StringAttribute{
+				Description:         "The password of the identity. This value is never stored in the state. Change 'password_wo_version' in order to update the password. Requires Terraform 1.11 or later.",
+				MarkdownDescription: "The password of the identity. This value is never stored in the state. Change `password_wo_version` in order to update the password. Requires Terraform 1.11 or later.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password")),
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"password_wo_version": schema.Int64Attribute{
+				Description:         "The version of the 'password_wo' attribute. Change this value in order to send a new password to Migadu.",
+				MarkdownDescription: "The version of the `password_wo` attribute. Change this value in order to send a new password to Migadu.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
+			},
 			"password_use": schema.StringAttribute{
 				Description:         "Configures the password use of the identity. Use 'none' if you just need to be able to send using a specific 'From' identity, but still authenticate with the mailbox address and password. Use 'mailbox' if you want an alternative address but linked to the same mailbox using the same password. Use 'custom' if you need an application specific password (e.g. your phone), shared mailbox with individual passwords or sandboxing of accounts for specific services.",
 				MarkdownDescription: "Configures the password use of the identity. Use `none` if you just need to be able to send using a specific `From` identity, but still authenticate with the mailbox address and password. Use `mailbox` if you want an alternative address but linked to the same mailbox using the same password. Use `custom` if you need an application specific password (e.g. your phone), shared mailbox with individual passwords or sandboxing of accounts for specific services.",
@@ -218,31 +260,87 @@ func (r *IdentityResource) Schema(_ context.Context, _ resource.SchemaRequest, r
 				Optional:            true,
 				Computed:            true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description:         "Whether to adopt an existing identity with the same identifier instead of failing to create it, e.g. after a previous apply timed out after the identity was created. The adopted identity is updated to match this resource. Defaults to the 'adopt_existing' attribute of the provider.",
+				MarkdownDescription: "Whether to adopt an existing identity with the same identifier instead of failing to create it, e.g. after a previous apply timed out after the identity was created. The adopted identity is updated to match this resource. Defaults to the `adopt_existing` attribute of the provider.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *IdentityResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the identity.",
+				RequiredForImport: true,
+			},
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the identity.",
+				RequiredForImport: true,
+			},
+			"identity": identityschema.StringAttribute{
+				Description:       "The local part of the identity.",
+				RequiredForImport: true,
+			},
 		},
 	}
 }
+
+func (r *IdentityResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: upgradeStateFromRawState,
+		},
+	}
+}
+
 func (r *IdentityResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
 	if request.ProviderData == nil {
 		return
 	}
 
-	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
-		r.MigaduClient = migaduClient
+	if providerData, ok := request.ProviderData.(*MigaduProviderData); ok {
+		r.MigaduClient = providerData.Client
+		r.AdoptExisting = providerData.AdoptExisting
 	} else {
 		response.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *provider.MigaduProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 	}
 }
 
 func (r *IdentityResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var plan IdentityResourceModel
 	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var passwordWO types.String
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	if plan.Password.IsUnknown() {
 		plan.Password = types.StringNull()
 	}
@@ -255,7 +353,7 @@ func (r *IdentityResource) Create(ctx context.Context, request resource.CreateRe
 		MayAccessImap:        plan.MayAccessImap.ValueBool(),
 		MayAccessPop3:        plan.MayAccessPop3.ValueBool(),
 		MayAccessManageSieve: plan.MayAccessManageSieve.ValueBool(),
-		Password:             plan.Password.ValueString(),
+		Password:             cmp.Or(plan.Password.ValueString(), passwordWO.ValueString()),
 		PasswordUse:          plan.PasswordUse.ValueString(),
 		FooterActive:         plan.FooterActive.ValueBool(),
 		FooterPlainBody:      plan.FooterPlainBody.ValueString(),
@@ -263,8 +361,14 @@ func (r *IdentityResource) Create(ctx context.Context, request resource.CreateRe
 	}
 
 	createdIdentity, err := r.MigaduClient.CreateIdentity(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), identity)
-	if err != nil {
-		response.Diagnostics.Append(IdentityCreateError(err))
+	if err != nil && isConflictError(err) && adoptExisting(plan.AdoptExisting, r.AdoptExisting) {
+		createdIdentity, diags = r.adopt(ctx, &plan, identity, err)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	} else if err != nil {
+		response.Diagnostics.Append(IdentityCreateError(ctx, err)...)
 		return
 	}
 
@@ -281,15 +385,53 @@ func (r *IdentityResource) Create(ctx context.Context, request resource.CreateRe
 	plan.FooterHtmlBody = types.StringValue(createdIdentity.FooterHtmlBody)
 
 	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newIdentityResourceIdentityModel(&plan))...)
+}
+
+// adopt updates the existing identity of the given plan to match the given identity. The given error of the failed create
+// request is reported in case no identity with the same address exists, e.g. because an alias uses the address.
+func (r *IdentityResource) adopt(ctx context.Context, plan *IdentityResourceModel, identity *model.Identity, createErr error) (*model.Identity, diag.Diagnostics) {
+	createDiagnostics := IdentityCreateError(ctx, createErr)
+
+	_, err := r.MigaduClient.GetIdentity(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), plan.Identity.ValueString())
+	if isNotFoundError(err) {
+		return nil, createDiagnostics
+	}
+	if err != nil {
+		return nil, IdentityReadError(ctx, err)
+	}
+
+	tflog.Info(ctx, "Adopting existing identity", map[string]interface{}{
+		"identity":    plan.Identity.ValueString(),
+		"local_part":  plan.LocalPart.ValueString(),
+		"domain_name": plan.DomainName.ValueString(),
+	})
+
+	updatedIdentity, err := r.MigaduClient.UpdateIdentity(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), plan.Identity.ValueString(), identity)
+	if err != nil {
+		return nil, IdentityUpdateError(ctx, err)
+	}
+	return updatedIdentity, nil
 }
 
 func (r *IdentityResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var state IdentityResourceModel
 	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	identity, err := r.MigaduClient.GetIdentity(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString(), state.Identity.ValueString())
 	if err != nil {
 		var requestError *client.RequestError
@@ -299,10 +441,18 @@ func (r *IdentityResource) Read(ctx context.Context, request resource.ReadReques
 				return
 			}
 		}
-		response.Diagnostics.Append(IdentityReadError(err))
+		response.Diagnostics.Append(IdentityReadError(ctx, err)...)
 		return
 	}
 
+	refreshIdentityResourceModel(&state, identity)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newIdentityResourceIdentityModel(&state))...)
+}
+
+// refreshIdentityResourceModel updates the given state with the values of the given identity.
+func refreshIdentityResourceModel(state *IdentityResourceModel, identity *model.Identity) {
 	state.ID = types.StringValue(CreateIdentityID(state.LocalPart, state.DomainName, state.Identity))
 	state.Address = custom_types.NewEmailAddressValue(identity.Address)
 	state.Name = types.StringValue(identity.Name)
@@ -318,17 +468,40 @@ func (r *IdentityResource) Read(ctx context.Context, request resource.ReadReques
 	state.FooterActive = types.BoolValue(identity.FooterActive)
 	state.FooterPlainBody = types.StringValue(identity.FooterPlainBody)
 	state.FooterHtmlBody = types.StringValue(identity.FooterHtmlBody)
+}
 
-	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+func newIdentityResourceIdentityModel(state *IdentityResourceModel) IdentityResourceIdentityModel {
+	return IdentityResourceIdentityModel{
+		LocalPart:  types.StringValue(state.LocalPart.ValueString()),
+		DomainName: types.StringValue(state.DomainName.ValueString()),
+		Identity:   types.StringValue(state.Identity.ValueString()),
+	}
 }
 
 func (r *IdentityResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var plan IdentityResourceModel
 	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var state IdentityResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
 	if plan.Password.IsUnknown() {
 		plan.Password = types.StringNull()
 	}
@@ -347,9 +520,45 @@ func (r *IdentityResource) Update(ctx context.Context, request resource.UpdateRe
 		FooterHtmlBody:       plan.FooterHtmlBody.ValueString(),
 	}
 
-	updatedIdentity, err := r.MigaduClient.UpdateIdentity(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), plan.Identity.ValueString(), identity)
+	// only changed fields are sent in order to keep changes made outside of Terraform
+	fields := newRequestFields(identity)
+	fields.add(&identity.Name, plan.Name, state.Name)
+	fields.add(&identity.MaySend, plan.MaySend, state.MaySend)
+	fields.add(&identity.MayReceive, plan.MayReceive, state.MayReceive)
+	fields.add(&identity.MayAccessImap, plan.MayAccessImap, state.MayAccessImap)
+	fields.add(&identity.MayAccessPop3, plan.MayAccessPop3, state.MayAccessPop3)
+	fields.add(&identity.MayAccessManageSieve, plan.MayAccessManageSieve, state.MayAccessManageSieve)
+	if identity.Password != "" {
+		fields.add(&identity.Password, plan.Password, state.Password)
+	}
+	fields.add(&identity.PasswordUse, plan.PasswordUse, state.PasswordUse)
+	fields.add(&identity.FooterActive, plan.FooterActive, state.FooterActive)
+	fields.add(&identity.FooterPlainBody, plan.FooterPlainBody, state.FooterPlainBody)
+	fields.add(&identity.FooterHtmlBody, plan.FooterHtmlBody, state.FooterHtmlBody)
+
+	// write-only passwords are only sent once their version changes
+	if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		var passwordWO types.String
+		response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		if password := cmp.Or(plan.Password.ValueString(), passwordWO.ValueString()); password != "" {
+			identity.Password = password
+			fields.addAlways(&identity.Password)
+		}
+	}
+
+	var updatedIdentity *model.Identity
+	var err error
+	if fields.empty() {
+		// nothing to send in case only attributes of this provider changed, e.g. the timeouts
+		updatedIdentity, err = r.MigaduClient.GetIdentity(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), plan.Identity.ValueString())
+	} else {
+		updatedIdentity, err = r.MigaduClient.UpdateIdentity(fields.context(ctx), plan.DomainName.ValueString(), plan.LocalPart.ValueString(), plan.Identity.ValueString(), identity)
+	}
 	if err != nil {
-		response.Diagnostics.Append(IdentityUpdateError(err))
+		response.Diagnostics.Append(IdentityUpdateError(ctx, err)...)
 		return
 	}
 
@@ -370,20 +579,60 @@ func (r *IdentityResource) Update(ctx context.Context, request resource.UpdateRe
 }
 
 func (r *IdentityResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var state IdentityResourceModel
 	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	_, err := r.MigaduClient.DeleteIdentity(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString(), state.Identity.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(IdentityDeleteError(err))
+		response.Diagnostics.Append(IdentityDeleteError(ctx, err)...)
 		return
 	}
 }
 
 func (r *IdentityResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID == "" && request.Identity != nil && !request.Identity.Raw.IsNull() {
+		var resourceIdentity IdentityResourceIdentityModel
+		response.Diagnostics.Append(request.Identity.Get(ctx, &resourceIdentity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		localPart := resourceIdentity.LocalPart.ValueString()
+		domainName := resourceIdentity.DomainName.ValueString()
+		identity := resourceIdentity.Identity.ValueString()
+
+		if localPart == "" || domainName == "" || identity == "" {
+			response.Diagnostics.Append(IdentityImportIdentityError())
+			return
+		}
+
+		tflog.Trace(ctx, "read import identity", map[string]interface{}{
+			"local_part":  localPart,
+			"domain_name": domainName,
+			"identity":    identity,
+		})
+
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), CreateIdentityIDString(localPart, domainName, identity))...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("identity"), identity)...)
+		return
+	}
+
 	idParts := strings.Split(request.ID, "@")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
@@ -8,12 +8,18 @@ package provider_test
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/metio/migadu-client.go/model"
 	"github.com/metio/migadu-client.go/simulator"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"regexp"
 	"testing"
+	"time"
 )
 
 func TestIdentityResource_API_Success_With_Password(t *testing.T) {
@@ -411,6 +417,290 @@ func TestIdentityResource_API_Success_With_Default_PasswordUse(t *testing.T) {
 	}
 }
 
+func TestIdentityResource_API_Success_With_WriteOnly_Password(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	identity := func(version int) string {
+		return fmt.Sprintf(`
+			resource "migadu_identity" "test" {
+				domain_name         = "example.com"
+				local_part          = "test"
+				identity            = "other"
+				name                = "Some Name"
+				password_use        = "custom"
+				password_wo         = "secret-%d"
+				password_wo_version = %d
+			}
+		`, version, version)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + identity(1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_identity.test", "id", "test@example.com/other"),
+					resource.TestCheckNoResourceAttr("migadu_identity.test", "password_wo"),
+					resource.TestCheckNoResourceAttr("migadu_identity.test", "password"),
+					resource.TestCheckResourceAttr("migadu_identity.test", "password_wo_version", "1"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + identity(2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("migadu_identity.test", "password_wo"),
+					resource.TestCheckResourceAttr("migadu_identity.test", "password_wo_version", "2"),
+				),
+			},
+			{
+				Config:   providerConfig(server.URL) + identity(2),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestIdentityResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{
+		Mailboxes: []model.Mailbox{
+			{
+				LocalPart:  "test",
+				DomainName: "example.com",
+				Address:    "test@example.com",
+			},
+		},
+	}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_identity" "test" {
+						local_part   = "test"
+						domain_name  = "example.com"
+						identity     = "other"
+						name         = "Some Name"
+						password_use = "none"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("migadu_identity.test", map[string]knownvalue.Check{
+						"local_part":  knownvalue.StringExact("test"),
+						"domain_name": knownvalue.StringExact("example.com"),
+						"identity":    knownvalue.StringExact("other"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "migadu_identity.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestIdentityResource_API_Timeouts(t *testing.T) {
+	api := newLatencyInjector(simulator.MigaduAPI(t, &simulator.State{
+		Mailboxes: []model.Mailbox{
+			{
+				LocalPart:  "test",
+				DomainName: "example.com",
+				Address:    "test@example.com",
+			},
+		},
+	}))
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	config := func(value string) string {
+		return providerConfig(server.URL) + fmt.Sprintf(`
+			resource "migadu_identity" "test" {
+				local_part   = "test"
+				domain_name  = "example.com"
+				identity     = "other"
+				name         = "%s"
+				password_use = "none"
+
+				timeouts {
+					create = "1s"
+					read   = "1s"
+					update = "1s"
+					delete = "1s"
+				}
+			}
+		`, value)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { api.Inject(http.MethodPost, 2*time.Second) },
+				Config:      config("Some Name"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig: func() { api.Inject("", 0) },
+				Config:    config("Some Name"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodGet, 2*time.Second) },
+				Config:      config("Some Name"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodPut, 2*time.Second) },
+				Config:      config("Other Name"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodDelete, 2*time.Second) },
+				Config:      config("Other Name"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig: func() { api.Inject("", 0) },
+				Config:    config("Other Name"),
+			},
+		},
+	})
+}
+
+func TestIdentityResource_API_PartialUpdate(t *testing.T) {
+	api := newBodyRecorder(simulator.MigaduAPI(t, &simulator.State{}), http.MethodPut)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_identity" "test" {
+						local_part   = "test"
+						domain_name  = "example.com"
+						identity     = "someone"
+						name         = "Old Name"
+						password     = "supers3cret"
+						password_use = "custom"
+					}
+				`,
+			},
+			{
+				PreConfig: func() { api.Fields() },
+				Config: providerConfig(server.URL) + `
+					resource "migadu_identity" "test" {
+						local_part   = "test"
+						domain_name  = "example.com"
+						identity     = "someone"
+						name         = "New Name"
+						password     = "supers3cret"
+						password_use = "custom"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_identity.test", "name", "New Name"),
+					func(_ *terraform.State) error {
+						if got, want := api.Fields(), [][]string{{"name"}}; !reflect.DeepEqual(got, want) {
+							return fmt.Errorf("expected update requests with fields %v, got %v", want, got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestIdentityResource_API_AdoptExisting(t *testing.T) {
+	testCases := map[string]struct {
+		provider string
+		resource string
+	}{
+		"resource": {
+			resource: "adopt_existing = true",
+		},
+		"provider": {
+			provider: "adopt_existing = true",
+		},
+		"resource-overrides-provider": {
+			provider: "adopt_existing = false",
+			resource: "adopt_existing = true",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(conflictOnCreate(simulator.MigaduAPI(t, &simulator.State{
+				Identities: []model.Identity{
+					{
+						LocalPart:   "someone",
+						DomainName:  "example.com",
+						Address:     "someone@example.com",
+						Name:        "Old Name",
+						PasswordUse: "mailbox",
+					},
+				},
+			})))
+			defer server.Close()
+
+			config := func(adopt bool) string {
+				providerSettings, resourceSettings := "", ""
+				if adopt {
+					providerSettings, resourceSettings = testCase.provider, testCase.resource
+				}
+				return fmt.Sprintf(`
+					provider "migadu" {
+						username = "username"
+						token    = "token"
+						endpoint = "%s"
+						%s
+					}
+
+					resource "migadu_identity" "test" {
+						local_part   = "test"
+						domain_name  = "example.com"
+						identity     = "someone"
+						name         = "Some Name"
+						password     = "supers3cret"
+						password_use = "custom"
+						%s
+					}
+				`, server.URL, providerSettings, resourceSettings)
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      config(false),
+						ExpectError: regexp.MustCompile("Error Creating Identity: Conflict"),
+					},
+					{
+						Config: config(true),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_identity.test", "address", "someone@example.com"),
+							resource.TestCheckResourceAttr("migadu_identity.test", "name", "Some Name"),
+							resource.TestCheckResourceAttr("migadu_identity.test", "password_use", "custom"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
 func TestIdentityResource_API_Errors(t *testing.T) {
 	testCases := map[string]APIErrorTestCase{
 		"error-404": {
@@ -542,6 +832,40 @@ func TestIdentityResource_Configuration_Errors(t *testing.T) {
 			`,
 			error: `Attribute name string length must be at least 1, got: 0`,
 		},
+		{
+			name: "password-and-write-only-password",
+			configuration: `
+				domain_name = "example.com"
+				local_part  = "test"
+				identity    = "some"
+				name        = "Some Name"
+				password    = "secret"
+				password_wo = "secret"
+			`,
+			error: `Attribute "password" cannot be specified when "password_wo" is\s+specified`,
+		},
+		{
+			name: "empty-write-only-password",
+			configuration: `
+				domain_name = "example.com"
+				local_part  = "test"
+				identity    = "some"
+				name        = "Some Name"
+				password_wo = ""
+			`,
+			error: "Attribute password_wo string length must be at least 1",
+		},
+		{
+			name: "write-only-password-version-without-password",
+			configuration: `
+				domain_name         = "example.com"
+				local_part          = "test"
+				identity            = "some"
+				name                = "Some Name"
+				password_wo_version = 1
+			`,
+			error: `Attribute "password_wo" must be specified when "password_wo_version" is\s+specified`,
+		},
 		{
 			name: "wrong-password-use",
 			configuration: `
@@ -0,0 +1,141 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/time/rate"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AccountLimitSettings configures the request budget of a single Migadu account.
+type AccountLimitSettings struct {
+	// RateLimit is the number of requests allowed per RateInterval. Set to 0 to disable rate limiting.
+	RateLimit int
+	// RateInterval is the interval over which RateLimit requests are allowed.
+	RateInterval time.Duration
+	// MaxConcurrentRequests is the number of requests that may be in flight at the same time. Set to 0 to disable the
+	// limit.
+	MaxConcurrentRequests int
+}
+
+// AccountLimiter enforces the rate and concurrency limits of a single Migadu account.
+type AccountLimiter struct {
+	settings    AccountLimitSettings
+	rateLimiter *rate.Limiter
+	concurrency chan struct{}
+}
+
+func NewAccountLimiter(settings AccountLimitSettings) *AccountLimiter {
+	limiter := &AccountLimiter{
+		settings: settings,
+	}
+	if settings.RateLimit > 0 {
+		limiter.rateLimiter = rate.NewLimiter(rate.Every(settings.RateInterval/time.Duration(settings.RateLimit)), settings.RateLimit)
+	}
+	if settings.MaxConcurrentRequests > 0 {
+		limiter.concurrency = make(chan struct{}, settings.MaxConcurrentRequests)
+	}
+	return limiter
+}
+
+// accountLimiters contains the limiters of all accounts used by the provider instances of this plugin process.
+var accountLimiters = struct {
+	sync.Mutex
+	limiters map[string]*AccountLimiter
+}{
+	limiters: map[string]*AccountLimiter{},
+}
+
+// SharedAccountLimiter returns the limiter of the account identified by the given endpoint and username. All provider
+// instances using the same account share a single limiter, so that their combined requests stay within the budget of
+// the account. The settings of the first provider instance apply to all others.
+func SharedAccountLimiter(ctx context.Context, endpoint, username string, settings AccountLimitSettings) *AccountLimiter {
+	key := strings.TrimSuffix(strings.ToLower(endpoint), "/") + "|" + strings.ToLower(username)
+
+	accountLimiters.Lock()
+	defer accountLimiters.Unlock()
+
+	limiter, ok := accountLimiters.limiters[key]
+	if !ok {
+		limiter = NewAccountLimiter(settings)
+		accountLimiters.limiters[key] = limiter
+	} else if limiter.settings != settings {
+		tflog.Warn(ctx, "Another provider configuration uses the same Migadu account with different limits, using the limits of the first configuration", map[string]interface{}{
+			"rate_limit":              limiter.settings.RateLimit,
+			"rate_interval":           limiter.settings.RateInterval.String(),
+			"max_concurrent_requests": limiter.settings.MaxConcurrentRequests,
+		})
+	}
+	return limiter
+}
+
+// NewLimitTransport wraps the given transport and delays requests until they fit into the budget of the given
+// account limiter. A request counts as in flight until its response body is closed.
+func NewLimitTransport(next http.RoundTripper, limiter *AccountLimiter) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &limitTransport{
+		next:    next,
+		limiter: limiter,
+	}
+}
+
+type limitTransport struct {
+	next    http.RoundTripper
+	limiter *AccountLimiter
+}
+
+func (t *limitTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+
+	release := func() {}
+	if t.limiter.concurrency != nil {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case t.limiter.concurrency <- struct{}{}:
+		}
+		var once sync.Once
+		release = func() {
+			once.Do(func() {
+				<-t.limiter.concurrency
+			})
+		}
+	}
+
+	if t.limiter.rateLimiter != nil {
+		if err := t.limiter.rateLimiter.Wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	response, err := t.next.RoundTrip(request)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	response.Body = &releaseOnCloseBody{ReadCloser: response.Body, release: release}
+	return response, nil
+}
+
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
@@ -0,0 +1,168 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	internal "github.com/metio/terraform-provider-migadu/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLimitTransport_MaxConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			previous := maxInFlight.Load()
+			if current <= previous || maxInFlight.CompareAndSwap(previous, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	limiter := internal.NewAccountLimiter(internal.AccountLimitSettings{
+		MaxConcurrentRequests: 2,
+	})
+	httpClient := &http.Client{
+		Transport: internal.NewLimitTransport(http.DefaultTransport, limiter),
+	}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			response, err := httpClient.Get(server.URL)
+			if assert.NoError(t, err, "Get") {
+				_ = response.Body.Close()
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, maxInFlight.Load(), int32(2), "max in flight")
+}
+
+func TestLimitTransport_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	limiter := internal.NewAccountLimiter(internal.AccountLimitSettings{
+		RateLimit:    2,
+		RateInterval: 200 * time.Millisecond,
+	})
+	httpClient := &http.Client{
+		Transport: internal.NewLimitTransport(http.DefaultTransport, limiter),
+	}
+
+	start := time.Now()
+	for range 4 {
+		response, err := httpClient.Get(server.URL)
+		if assert.NoError(t, err, "Get") {
+			_ = response.Body.Close()
+		}
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond, "elapsed")
+}
+
+func TestLimitTransport_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	limiter := internal.NewAccountLimiter(internal.AccountLimitSettings{
+		MaxConcurrentRequests: 1,
+	})
+	httpClient := &http.Client{
+		Transport: internal.NewLimitTransport(http.DefaultTransport, limiter),
+	}
+
+	go func() {
+		response, err := httpClient.Get(server.URL)
+		if err == nil {
+			_ = response.Body.Close()
+		}
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.NoError(t, err, "NewRequest")
+
+	_, err = httpClient.Do(request)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Do")
+}
+
+func TestSharedAccountLimiter(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	settings := internal.AccountLimitSettings{RateLimit: 60, RateInterval: 2 * time.Minute}
+
+	limiter := internal.SharedAccountLimiter(ctx, "https://shared.example.com/v1/", "someone@example.com", settings)
+
+	testCases := map[string]struct {
+		endpoint string
+		username string
+		settings internal.AccountLimitSettings
+		shared   bool
+	}{
+		"same-account": {
+			endpoint: "https://shared.example.com/v1/",
+			username: "someone@example.com",
+			settings: settings,
+			shared:   true,
+		},
+		"same-account-different-spelling": {
+			endpoint: "https://SHARED.example.com/v1",
+			username: "SomeOne@example.com",
+			settings: settings,
+			shared:   true,
+		},
+		"same-account-different-settings": {
+			endpoint: "https://shared.example.com/v1/",
+			username: "someone@example.com",
+			settings: internal.AccountLimitSettings{RateLimit: 10, RateInterval: time.Minute},
+			shared:   true,
+		},
+		"other-username": {
+			endpoint: "https://shared.example.com/v1/",
+			username: "other@example.com",
+			settings: settings,
+			shared:   false,
+		},
+		"other-endpoint": {
+			endpoint: "https://other.example.com/v1/",
+			username: "someone@example.com",
+			settings: settings,
+			shared:   false,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := internal.SharedAccountLimiter(ctx, testCase.endpoint, testCase.username, testCase.settings)
+			assert.Equal(t, testCase.shared, got == limiter, "shared")
+		})
+	}
+}
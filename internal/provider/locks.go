@@ -0,0 +1,51 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"fmt"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+	"strings"
+	"sync"
+)
+
+// objectLocks serializes read-modify-write cycles of non-authoritative resources that modify the same remote object.
+var objectLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{
+	locks: map[string]*sync.Mutex{},
+}
+
+// lockObject acquires the lock of the given object and returns a function that releases it. Unicode and punycode
+// domain names map to the same lock.
+func lockObject(kind, localPart, domainName string) func() {
+	key := objectLockKey(kind, localPart, domainName)
+
+	objectLocks.Lock()
+	lock, ok := objectLocks.locks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		objectLocks.locks[key] = lock
+	}
+	objectLocks.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+func objectLockKey(kind, localPart, domainName string) string {
+	return fmt.Sprintf("%s:%s@%s", kind, strings.ToLower(strings.TrimSpace(localPart)), canonicalDomainName(domainName))
+}
+
+// canonicalDomainName returns the lower-case punycode form of the given domain name, or the lower-case domain name in
+// case it cannot be converted to punycode.
+func canonicalDomainName(domainName string) string {
+	if domain, err := custom_types.NormalizeDomain(domainName); err == nil {
+		return domain
+	}
+	return strings.ToLower(strings.TrimSpace(domainName))
+}
@@ -6,12 +6,43 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
 )
 
+// mailboxAPIAttributes maps API error fields to the attributes of the mailbox resource.
+var mailboxAPIAttributes = map[string]string{
+	"local_part":              "local_part",
+	"name":                    "name",
+	"is_internal":             "is_internal",
+	"may_send":                "may_send",
+	"may_receive":             "may_receive",
+	"may_access_imap":         "may_access_imap",
+	"may_access_managesieve":  "may_access_manage_sieve",
+	"password":                "password",
+	"password_recovery_email": "password_recovery_email",
+	"password_method":         "password_method",
+	"spam_action":             "spam_action",
+	"spam_aggressiveness":     "spam_aggressiveness",
+	"expireable":              "expirable",
+	"expires_on":              "expires_on",
+	"remove_upon_expiry":      "remove_upon_expiry",
+	"sender_denylist":         "sender_denylist",
+	"sender_allowlist":        "sender_allowlist",
+	"recipient_denylist":      "recipient_denylist",
+	"delegations":             "delegations",
+	"autorespond_active":      "auto_respond_active",
+	"autorespond_subject":     "auto_respond_subject",
+	"autorespond_body":        "auto_respond_body",
+	"autorespond_expires_on":  "auto_respond_expires_on",
+	"footer_active":           "footer_active",
+	"footer_plain_body":       "footer_plain_body",
+	"footer_html_body":        "footer_html_body",
+}
+
 func CreateMailboxID(localPart types.String, domainName custom_types.DomainNameValue) string {
 	return CreateMailboxIDString(localPart.ValueString(), domainName.ValueString())
 }
@@ -20,32 +51,20 @@ func CreateMailboxIDString(localPart, domainName string) string {
 	return fmt.Sprintf("%s@%s", localPart, domainName)
 }
 
-func MailboxCreateError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Creating Mailbox",
-		standardAPIErrorDetail(err),
-	)
+func MailboxCreateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Creating Mailbox", err, mailboxAPIAttributes)
 }
 
-func MailboxReadError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Reading Mailbox",
-		standardAPIErrorDetail(err),
-	)
+func MailboxReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Mailbox", err, mailboxAPIAttributes)
 }
 
-func MailboxUpdateError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Updating Mailbox",
-		standardAPIErrorDetail(err),
-	)
+func MailboxUpdateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Updating Mailbox", err, mailboxAPIAttributes)
 }
 
-func MailboxDeleteError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Deleting Mailbox",
-		standardAPIErrorDetail(err),
-	)
+func MailboxDeleteError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Deleting Mailbox", err, mailboxAPIAttributes)
 }
 
 func MailboxImportError(id string) diag.Diagnostic {
@@ -54,3 +73,10 @@ func MailboxImportError(id string) diag.Diagnostic {
 		standardImportErrorDetail("local_part@domain_name", id),
 	)
 }
+
+func MailboxImportIdentityError() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Mailbox",
+		standardImportIdentityErrorDetail("local_part", "domain_name"),
+	)
+}
@@ -0,0 +1,49 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// mailboxAutoresponderAPIAttributes maps API error fields to the attributes of the mailbox autoresponder resource.
+var mailboxAutoresponderAPIAttributes = map[string]string{
+	"autorespond_active":     "active",
+	"autorespond_subject":    "subject",
+	"autorespond_body":       "body",
+	"autorespond_expires_on": "expires_on",
+}
+
+func MailboxAutoresponderCreateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Creating Mailbox Autoresponder", err, mailboxAutoresponderAPIAttributes)
+}
+
+func MailboxAutoresponderReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Mailbox Autoresponder", err, mailboxAutoresponderAPIAttributes)
+}
+
+func MailboxAutoresponderUpdateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Updating Mailbox Autoresponder", err, mailboxAutoresponderAPIAttributes)
+}
+
+func MailboxAutoresponderDeleteError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Deleting Mailbox Autoresponder", err, mailboxAutoresponderAPIAttributes)
+}
+
+func MailboxAutoresponderImportError(id string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Mailbox Autoresponder",
+		standardImportErrorDetail("local_part@domain_name", id),
+	)
+}
+
+func MailboxAutoresponderImportIdentityError() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Mailbox Autoresponder",
+		standardImportIdentityErrorDetail("local_part", "domain_name"),
+	)
+}
@@ -0,0 +1,377 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+	"net/http"
+	"strings"
+)
+
+var (
+	_ resource.Resource                = (*MailboxAutoresponderResource)(nil)
+	_ resource.ResourceWithConfigure   = (*MailboxAutoresponderResource)(nil)
+	_ resource.ResourceWithImportState = (*MailboxAutoresponderResource)(nil)
+	_ resource.ResourceWithIdentity    = (*MailboxAutoresponderResource)(nil)
+)
+
+func NewMailboxAutoresponderResource() resource.Resource {
+	return &MailboxAutoresponderResource{}
+}
+
+type MailboxAutoresponderResource struct {
+	MigaduClient *client.MigaduClient
+	ReadCache    *ReadCache
+}
+
+type MailboxAutoresponderResourceModel struct {
+	ID         custom_types.EmailAddressValue `tfsdk:"id"`
+	LocalPart  types.String                   `tfsdk:"local_part"`
+	DomainName custom_types.DomainNameValue   `tfsdk:"domain_name"`
+	Active     types.Bool                     `tfsdk:"active"`
+	Subject    types.String                   `tfsdk:"subject"`
+	Body       types.String                   `tfsdk:"body"`
+	ExpiresOn  types.String                   `tfsdk:"expires_on"`
+}
+
+type MailboxAutoresponderResourceIdentityModel struct {
+	LocalPart  types.String `tfsdk:"local_part"`
+	DomainName types.String `tfsdk:"domain_name"`
+}
+
+func (r *MailboxAutoresponderResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_mailbox_autoresponder"
+}
+
+func (r *MailboxAutoresponderResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Provides the automatic response of an existing mailbox. Set 'manage_autoresponder = false' on the 'migadu_mailbox' resource of the same mailbox to avoid conflicting changes. Destroying this resource turns the automatic response off.",
+		MarkdownDescription: "Provides the automatic response of an existing mailbox. Set `manage_autoresponder = false` on the `migadu_mailbox` resource of the same mailbox to avoid conflicting changes. Destroying this resource turns the automatic response off.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Contains the value 'local_part@domain_name'.",
+				MarkdownDescription: "Contains the value `local_part@domain_name`.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				CustomType:          custom_types.EmailAddressType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"local_part": schema.StringAttribute{
+				Description:         "The local part of the mailbox.",
+				MarkdownDescription: "The local part of the mailbox.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain name of the mailbox.",
+				MarkdownDescription: "The domain name of the mailbox.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"active": schema.BoolAttribute{
+				Description:         "Whether the automatic response is active. Defaults to 'true'.",
+				MarkdownDescription: "Whether the automatic response is active. Defaults to `true`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"subject": schema.StringAttribute{
+				Description:         "The subject of the automatic response.",
+				MarkdownDescription: "The subject of the automatic response.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"body": schema.StringAttribute{
+				Description:         "The body of the automatic response.",
+				MarkdownDescription: "The body of the automatic response.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"expires_on": schema.StringAttribute{
+				Description:         "The expiration date of the automatic response.",
+				MarkdownDescription: "The expiration date of the automatic response.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *MailboxAutoresponderResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the mailbox.",
+				RequiredForImport: true,
+			},
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the mailbox.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *MailboxAutoresponderResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if providerData, ok := request.ProviderData.(*MigaduProviderData); ok {
+		r.MigaduClient = providerData.Client
+		r.ReadCache = providerData.ReadCache
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.MigaduProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (r *MailboxAutoresponderResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var plan MailboxAutoresponderResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	unlock := lockObject("mailbox", plan.LocalPart.ValueString(), plan.DomainName.ValueString())
+	defer unlock()
+
+	mailbox, err := r.MigaduClient.GetMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(MailboxAutoresponderCreateError(ctx, err)...)
+		return
+	}
+
+	mailbox.AutoRespondActive = plan.Active.ValueBool()
+	mailbox.AutoRespondSubject = plan.Subject.ValueString()
+	mailbox.AutoRespondBody = plan.Body.ValueString()
+	mailbox.AutoRespondExpiresOn = plan.ExpiresOn.ValueString()
+
+	updatedMailbox, err := r.MigaduClient.UpdateMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), mailbox)
+	if err != nil {
+		response.Diagnostics.Append(MailboxAutoresponderCreateError(ctx, err)...)
+		return
+	}
+
+	plan.ID = custom_types.NewEmailAddressValue(CreateMailboxID(plan.LocalPart, plan.DomainName))
+	plan.Active = types.BoolValue(updatedMailbox.AutoRespondActive)
+	plan.Subject = types.StringValue(updatedMailbox.AutoRespondSubject)
+	plan.Body = types.StringValue(updatedMailbox.AutoRespondBody)
+	plan.ExpiresOn = types.StringValue(updatedMailbox.AutoRespondExpiresOn)
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newMailboxAutoresponderResourceIdentityModel(&plan))...)
+}
+
+func (r *MailboxAutoresponderResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state MailboxAutoresponderResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	mailbox, err := r.ReadCache.GetMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
+	if err != nil {
+		var requestError *client.RequestError
+		if errors.As(err, &requestError) {
+			if requestError.StatusCode == http.StatusNotFound {
+				response.State.RemoveResource(ctx)
+				return
+			}
+		}
+		response.Diagnostics.Append(MailboxAutoresponderReadError(ctx, err)...)
+		return
+	}
+
+	state.ID = custom_types.NewEmailAddressValue(CreateMailboxID(state.LocalPart, state.DomainName))
+	state.Active = types.BoolValue(mailbox.AutoRespondActive)
+	state.Subject = types.StringValue(mailbox.AutoRespondSubject)
+	state.Body = types.StringValue(mailbox.AutoRespondBody)
+	state.ExpiresOn = types.StringValue(mailbox.AutoRespondExpiresOn)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newMailboxAutoresponderResourceIdentityModel(&state))...)
+}
+
+func newMailboxAutoresponderResourceIdentityModel(state *MailboxAutoresponderResourceModel) MailboxAutoresponderResourceIdentityModel {
+	return MailboxAutoresponderResourceIdentityModel{
+		LocalPart:  types.StringValue(state.LocalPart.ValueString()),
+		DomainName: types.StringValue(state.DomainName.ValueString()),
+	}
+}
+
+func (r *MailboxAutoresponderResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var plan MailboxAutoresponderResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	unlock := lockObject("mailbox", plan.LocalPart.ValueString(), plan.DomainName.ValueString())
+	defer unlock()
+
+	mailbox, err := r.MigaduClient.GetMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(MailboxAutoresponderUpdateError(ctx, err)...)
+		return
+	}
+
+	mailbox.AutoRespondActive = plan.Active.ValueBool()
+	mailbox.AutoRespondSubject = plan.Subject.ValueString()
+	mailbox.AutoRespondBody = plan.Body.ValueString()
+	mailbox.AutoRespondExpiresOn = plan.ExpiresOn.ValueString()
+
+	updatedMailbox, err := r.MigaduClient.UpdateMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), mailbox)
+	if err != nil {
+		response.Diagnostics.Append(MailboxAutoresponderUpdateError(ctx, err)...)
+		return
+	}
+
+	plan.ID = custom_types.NewEmailAddressValue(CreateMailboxID(plan.LocalPart, plan.DomainName))
+	plan.Active = types.BoolValue(updatedMailbox.AutoRespondActive)
+	plan.Subject = types.StringValue(updatedMailbox.AutoRespondSubject)
+	plan.Body = types.StringValue(updatedMailbox.AutoRespondBody)
+	plan.ExpiresOn = types.StringValue(updatedMailbox.AutoRespondExpiresOn)
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+}
+
+func (r *MailboxAutoresponderResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state MailboxAutoresponderResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(state.DomainName.ValueString())
+
+	unlock := lockObject("mailbox", state.LocalPart.ValueString(), state.DomainName.ValueString())
+	defer unlock()
+
+	mailbox, err := r.MigaduClient.GetMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
+	if err != nil {
+		var requestError *client.RequestError
+		if errors.As(err, &requestError) {
+			if requestError.StatusCode == http.StatusNotFound {
+				return
+			}
+		}
+		response.Diagnostics.Append(MailboxAutoresponderDeleteError(ctx, err)...)
+		return
+	}
+
+	mailbox.AutoRespondActive = false
+
+	_, err = r.MigaduClient.UpdateMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString(), mailbox)
+	if err != nil {
+		response.Diagnostics.Append(MailboxAutoresponderDeleteError(ctx, err)...)
+		return
+	}
+}
+
+func (r *MailboxAutoresponderResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID == "" && request.Identity != nil && !request.Identity.Raw.IsNull() {
+		var resourceIdentity MailboxAutoresponderResourceIdentityModel
+		response.Diagnostics.Append(request.Identity.Get(ctx, &resourceIdentity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		localPart := resourceIdentity.LocalPart.ValueString()
+		domainName := resourceIdentity.DomainName.ValueString()
+
+		if localPart == "" || domainName == "" {
+			response.Diagnostics.Append(MailboxAutoresponderImportIdentityError())
+			return
+		}
+
+		tflog.Trace(ctx, "read import identity", map[string]interface{}{
+			"local_part":  localPart,
+			"domain_name": domainName,
+		})
+
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), CreateMailboxIDString(localPart, domainName))...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+		return
+	}
+
+	idParts := strings.Split(request.ID, "@")
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		response.Diagnostics.Append(MailboxAutoresponderImportError(request.ID))
+		return
+	}
+
+	localPart := idParts[0]
+	domainName := idParts[1]
+	tflog.Trace(ctx, "parsed import ID", map[string]interface{}{
+		"local_part":  localPart,
+		"domain_name": domainName,
+	})
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+}
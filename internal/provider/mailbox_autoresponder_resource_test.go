@@ -0,0 +1,377 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestMailboxAutoresponderResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	provider.NewMailboxAutoresponderResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestMailboxAutoresponderResource_API_Success(t *testing.T) {
+	testCases := map[string]ResourceTestCase[model.Mailbox]{
+		"change-subject": {
+			Create: ResourceTestStep[model.Mailbox]{
+				Send: model.Mailbox{
+					LocalPart:          "test",
+					DomainName:         "example.com",
+					AutoRespondSubject: "Out of office",
+					AutoRespondBody:    "I am on vacation.",
+				},
+				Want: model.Mailbox{
+					LocalPart:          "test",
+					DomainName:         "example.com",
+					AutoRespondActive:  true,
+					AutoRespondSubject: "Out of office",
+					AutoRespondBody:    "I am on vacation.",
+				},
+			},
+			Update: ResourceTestStep[model.Mailbox]{
+				Send: model.Mailbox{
+					LocalPart:          "test",
+					DomainName:         "example.com",
+					AutoRespondSubject: "Away",
+					AutoRespondBody:    "I am on vacation.",
+				},
+				Want: model.Mailbox{
+					LocalPart:          "test",
+					DomainName:         "example.com",
+					AutoRespondActive:  true,
+					AutoRespondSubject: "Away",
+					AutoRespondBody:    "I am on vacation.",
+				},
+			},
+		},
+		"idna": {
+			Create: ResourceTestStep[model.Mailbox]{
+				Send: model.Mailbox{
+					LocalPart:          "test",
+					DomainName:         "hoß.de",
+					AutoRespondSubject: "Out of office",
+					AutoRespondBody:    "I am on vacation.",
+				},
+				Want: model.Mailbox{
+					LocalPart:          "test",
+					DomainName:         "hoß.de",
+					AutoRespondActive:  true,
+					AutoRespondSubject: "Out of office",
+					AutoRespondBody:    "I am on vacation.",
+				},
+			},
+			Update: ResourceTestStep[model.Mailbox]{
+				Send: model.Mailbox{
+					LocalPart:          "test",
+					DomainName:         "hoß.de",
+					AutoRespondSubject: "Out of office",
+					AutoRespondBody:    "I am back on Monday.",
+				},
+				Want: model.Mailbox{
+					LocalPart:          "test",
+					DomainName:         "hoß.de",
+					AutoRespondActive:  true,
+					AutoRespondSubject: "Out of office",
+					AutoRespondBody:    "I am back on Monday.",
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{
+				Mailboxes: []model.Mailbox{
+					{
+						LocalPart:  "test",
+						DomainName: "example.com",
+						Address:    "test@example.com",
+						Name:       "Some Name",
+					},
+					{
+						LocalPart:  "test",
+						DomainName: "xn--ho-hia.de",
+						Address:    "test@xn--ho-hia.de",
+						Name:       "Some Name",
+					},
+				},
+			}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							resource "migadu_mailbox_autoresponder" "test" {
+								local_part  = "%s"
+								domain_name = "%s"
+								subject     = "%s"
+								body        = "%s"
+							}
+						`, testCase.Create.Send.LocalPart, testCase.Create.Send.DomainName, testCase.Create.Send.AutoRespondSubject, testCase.Create.Send.AutoRespondBody),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "id", fmt.Sprintf("%s@%s", testCase.Create.Want.LocalPart, testCase.Create.Want.DomainName)),
+							resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "local_part", testCase.Create.Want.LocalPart),
+							resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "domain_name", testCase.Create.Want.DomainName),
+							resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "active", fmt.Sprintf("%v", testCase.Create.Want.AutoRespondActive)),
+							resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "subject", testCase.Create.Want.AutoRespondSubject),
+							resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "body", testCase.Create.Want.AutoRespondBody),
+						),
+					},
+					{
+						ResourceName:            "migadu_mailbox_autoresponder.test",
+						ImportState:             true,
+						ImportStateVerify:       true,
+						ImportStateVerifyIgnore: testCase.ImportIgnore,
+					},
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							resource "migadu_mailbox_autoresponder" "test" {
+								local_part  = "%s"
+								domain_name = "%s"
+								subject     = "%s"
+								body        = "%s"
+							}
+						`, testCase.Update.Send.LocalPart, testCase.Update.Send.DomainName, testCase.Update.Send.AutoRespondSubject, testCase.Update.Send.AutoRespondBody),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "id", fmt.Sprintf("%s@%s", testCase.Update.Want.LocalPart, testCase.Update.Want.DomainName)),
+							resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "active", fmt.Sprintf("%v", testCase.Update.Want.AutoRespondActive)),
+							resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "subject", testCase.Update.Want.AutoRespondSubject),
+							resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "body", testCase.Update.Want.AutoRespondBody),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestMailboxAutoresponderResource_API_Success_With_Unmanaged_Mailbox(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	mailbox := func(name string) string {
+		return fmt.Sprintf(`
+			resource "migadu_mailbox" "test" {
+				local_part           = "test"
+				domain_name          = "example.com"
+				password             = "secret"
+				name                 = "%s"
+				manage_autoresponder = false
+			}
+		`, name)
+	}
+	autoresponder := `
+		resource "migadu_mailbox_autoresponder" "test" {
+			local_part  = migadu_mailbox.test.local_part
+			domain_name = migadu_mailbox.test.domain_name
+			subject     = "Out of office"
+			body        = "I am on vacation."
+		}
+	`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + mailbox("Some Name") + autoresponder,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "active", "true"),
+					resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "subject", "Out of office"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + mailbox("Different Name") + autoresponder,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "name", "Different Name"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "auto_respond_active", "true"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "auto_respond_subject", "Out of office"),
+					resource.TestCheckResourceAttr("migadu_mailbox_autoresponder.test", "active", "true"),
+				),
+			},
+			{
+				Config:   providerConfig(server.URL) + mailbox("Different Name") + autoresponder,
+				PlanOnly: true,
+			},
+			{
+				Config: providerConfig(server.URL) + mailbox("Different Name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "auto_respond_active", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestMailboxAutoresponderResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{
+		Mailboxes: []model.Mailbox{
+			{
+				LocalPart:  "test",
+				DomainName: "example.com",
+				Address:    "test@example.com",
+			},
+		},
+	}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_mailbox_autoresponder" "test" {
+						local_part  = "test"
+						domain_name = "example.com"
+						subject     = "Out of office"
+						body        = "I am on vacation."
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("migadu_mailbox_autoresponder.test", map[string]knownvalue.Check{
+						"local_part":  knownvalue.StringExact("test"),
+						"domain_name": knownvalue.StringExact("example.com"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "migadu_mailbox_autoresponder.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestMailboxAutoresponderResource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetMailbox: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetMailbox: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							resource "migadu_mailbox_autoresponder" "test" {
+								local_part  = "test"
+								domain_name = "example.com"
+								subject     = "Out of office"
+								body        = "I am on vacation."
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestMailboxAutoresponderResource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"empty-domain-name": {
+			Configuration: `
+				domain_name = ""
+				local_part  = "test"
+				subject     = "Out of office"
+				body        = "I am on vacation."
+			`,
+			ErrorRegex: "Attribute domain_name string length must be at least 1",
+		},
+		"empty-local-part": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = ""
+				subject     = "Out of office"
+				body        = "I am on vacation."
+			`,
+			ErrorRegex: "Attribute local_part string length must be at least 1",
+		},
+		"empty-subject": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "test"
+				subject     = ""
+				body        = "I am on vacation."
+			`,
+			ErrorRegex: "Attribute subject string length must be at least 1",
+		},
+		"missing-subject": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "test"
+				body        = "I am on vacation."
+			`,
+			ErrorRegex: `The argument "subject" is required, but no definition was found`,
+		},
+		"missing-body": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "test"
+				subject     = "Out of office"
+			`,
+			ErrorRegex: `The argument "body" is required, but no definition was found`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							resource "migadu_mailbox_autoresponder" "test" {
+								%s
+							}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
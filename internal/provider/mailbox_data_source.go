@@ -316,6 +316,8 @@ func (d *MailboxDataSource) Configure(_ context.Context, request datasource.Conf
 }
 
 func (d *MailboxDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var data MailboxDataSourceModel
 	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
 	if response.Diagnostics.HasError() {
@@ -324,7 +326,7 @@ func (d *MailboxDataSource) Read(ctx context.Context, request datasource.ReadReq
 
 	mailbox, err := d.MigaduClient.GetMailbox(ctx, data.DomainName.ValueString(), data.LocalPart.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(MailboxReadError(err))
+		response.Diagnostics.Append(MailboxReadError(ctx, err)...)
 		return
 	}
 
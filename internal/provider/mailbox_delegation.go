@@ -0,0 +1,53 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+// mailboxDelegationAPIAttributes maps API error fields to the attributes of the mailbox delegation resource.
+var mailboxDelegationAPIAttributes = map[string]string{
+	"delegations": "delegation",
+}
+
+func CreateMailboxDelegationID(localPart types.String, domainName custom_types.DomainNameValue, delegation custom_types.EmailAddressValue) string {
+	return CreateMailboxDelegationIDString(localPart.ValueString(), domainName.ValueString(), delegation.ValueString())
+}
+
+func CreateMailboxDelegationIDString(localPart, domainName, delegation string) string {
+	return fmt.Sprintf("%s@%s/%s", localPart, domainName, delegation)
+}
+
+func MailboxDelegationCreateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Creating Mailbox Delegation", err, mailboxDelegationAPIAttributes)
+}
+
+func MailboxDelegationReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Mailbox Delegation", err, mailboxDelegationAPIAttributes)
+}
+
+func MailboxDelegationDeleteError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Deleting Mailbox Delegation", err, mailboxDelegationAPIAttributes)
+}
+
+func MailboxDelegationImportError(id string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Mailbox Delegation",
+		standardImportErrorDetail("local_part@domain_name/delegation", id),
+	)
+}
+
+func MailboxDelegationImportIdentityError() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Mailbox Delegation",
+		standardImportIdentityErrorDetail("local_part", "domain_name", "delegation"),
+	)
+}
@@ -0,0 +1,355 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+	"net/http"
+	"strings"
+)
+
+var (
+	_ resource.Resource                = (*MailboxDelegationResource)(nil)
+	_ resource.ResourceWithConfigure   = (*MailboxDelegationResource)(nil)
+	_ resource.ResourceWithImportState = (*MailboxDelegationResource)(nil)
+	_ resource.ResourceWithIdentity    = (*MailboxDelegationResource)(nil)
+)
+
+func NewMailboxDelegationResource() resource.Resource {
+	return &MailboxDelegationResource{}
+}
+
+type MailboxDelegationResource struct {
+	MigaduClient *client.MigaduClient
+	ReadCache    *ReadCache
+}
+
+type MailboxDelegationResourceModel struct {
+	ID         types.String                   `tfsdk:"id"`
+	LocalPart  types.String                   `tfsdk:"local_part"`
+	DomainName custom_types.DomainNameValue   `tfsdk:"domain_name"`
+	Delegation custom_types.EmailAddressValue `tfsdk:"delegation"`
+}
+
+type MailboxDelegationResourceIdentityModel struct {
+	LocalPart  types.String `tfsdk:"local_part"`
+	DomainName types.String `tfsdk:"domain_name"`
+	Delegation types.String `tfsdk:"delegation"`
+}
+
+func (r *MailboxDelegationResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_mailbox_delegation"
+}
+
+func (r *MailboxDelegationResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Provides a single delegation of an existing mailbox. Other delegations of the mailbox are left untouched. Leave the 'delegations' attribute of the 'migadu_mailbox' resource of the same mailbox unset in order to avoid conflicting changes.",
+		MarkdownDescription: "Provides a single delegation of an existing mailbox. Other delegations of the mailbox are left untouched. Leave the `delegations` attribute of the `migadu_mailbox` resource of the same mailbox unset in order to avoid conflicting changes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Contains the value 'local_part@domain_name/delegation'.",
+				MarkdownDescription: "Contains the value `local_part@domain_name/delegation`.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"local_part": schema.StringAttribute{
+				Description:         "The local part of the mailbox.",
+				MarkdownDescription: "The local part of the mailbox.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain name of the mailbox.",
+				MarkdownDescription: "The domain name of the mailbox.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"delegation": schema.StringAttribute{
+				Description:         "The email address to add to the delegations of the mailbox.",
+				MarkdownDescription: "The email address to add to the delegations of the mailbox.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.EmailAddressType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *MailboxDelegationResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the mailbox.",
+				RequiredForImport: true,
+			},
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the mailbox.",
+				RequiredForImport: true,
+			},
+			"delegation": identityschema.StringAttribute{
+				Description:       "The email address to add to the delegations of the mailbox.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *MailboxDelegationResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if providerData, ok := request.ProviderData.(*MigaduProviderData); ok {
+		r.MigaduClient = providerData.Client
+		r.ReadCache = providerData.ReadCache
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.MigaduProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (r *MailboxDelegationResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var plan MailboxDelegationResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	unlock := lockObject("mailbox", plan.LocalPart.ValueString(), plan.DomainName.ValueString())
+	defer unlock()
+
+	mailbox, err := r.MigaduClient.GetMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(MailboxDelegationCreateError(ctx, err)...)
+		return
+	}
+
+	delegations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, mailbox.Delegations)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if !delegations.Contains(ctx, plan.Delegation) {
+		mailbox.Delegations = append(mailbox.Delegations, plan.Delegation.ValueString())
+
+		_, err = r.MigaduClient.UpdateMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), mailbox)
+		if err != nil {
+			response.Diagnostics.Append(MailboxDelegationCreateError(ctx, err)...)
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(CreateMailboxDelegationID(plan.LocalPart, plan.DomainName, plan.Delegation))
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newMailboxDelegationResourceIdentityModel(&plan))...)
+}
+
+func (r *MailboxDelegationResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state MailboxDelegationResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	mailbox, err := r.ReadCache.GetMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
+	if err != nil {
+		var requestError *client.RequestError
+		if errors.As(err, &requestError) {
+			if requestError.StatusCode == http.StatusNotFound {
+				response.State.RemoveResource(ctx)
+				return
+			}
+		}
+		response.Diagnostics.Append(MailboxDelegationReadError(ctx, err)...)
+		return
+	}
+
+	delegations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, mailbox.Delegations)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if !delegations.Contains(ctx, state.Delegation) {
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(CreateMailboxDelegationID(state.LocalPart, state.DomainName, state.Delegation))
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newMailboxDelegationResourceIdentityModel(&state))...)
+}
+
+func newMailboxDelegationResourceIdentityModel(state *MailboxDelegationResourceModel) MailboxDelegationResourceIdentityModel {
+	return MailboxDelegationResourceIdentityModel{
+		LocalPart:  types.StringValue(state.LocalPart.ValueString()),
+		DomainName: types.StringValue(state.DomainName.ValueString()),
+		Delegation: types.StringValue(state.Delegation.ValueString()),
+	}
+}
+
+func (r *MailboxDelegationResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan MailboxDelegationResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(CreateMailboxDelegationID(plan.LocalPart, plan.DomainName, plan.Delegation))
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+}
+
+func (r *MailboxDelegationResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state MailboxDelegationResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(state.DomainName.ValueString())
+
+	unlock := lockObject("mailbox", state.LocalPart.ValueString(), state.DomainName.ValueString())
+	defer unlock()
+
+	mailbox, err := r.MigaduClient.GetMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
+	if err != nil {
+		var requestError *client.RequestError
+		if errors.As(err, &requestError) {
+			if requestError.StatusCode == http.StatusNotFound {
+				return
+			}
+		}
+		response.Diagnostics.Append(MailboxDelegationDeleteError(ctx, err)...)
+		return
+	}
+
+	remainingDelegations := removeEmailAddress(ctx, mailbox.Delegations, state.Delegation)
+	if len(remainingDelegations) == len(mailbox.Delegations) {
+		return
+	}
+	mailbox.Delegations = remainingDelegations
+
+	_, err = r.MigaduClient.UpdateMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString(), mailbox)
+	if err != nil {
+		response.Diagnostics.Append(MailboxDelegationDeleteError(ctx, err)...)
+		return
+	}
+}
+
+func (r *MailboxDelegationResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID == "" && request.Identity != nil && !request.Identity.Raw.IsNull() {
+		var resourceIdentity MailboxDelegationResourceIdentityModel
+		response.Diagnostics.Append(request.Identity.Get(ctx, &resourceIdentity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		localPart := resourceIdentity.LocalPart.ValueString()
+		domainName := resourceIdentity.DomainName.ValueString()
+		delegation := resourceIdentity.Delegation.ValueString()
+
+		if localPart == "" || domainName == "" || delegation == "" {
+			response.Diagnostics.Append(MailboxDelegationImportIdentityError())
+			return
+		}
+
+		tflog.Trace(ctx, "read import identity", map[string]interface{}{
+			"local_part":  localPart,
+			"domain_name": domainName,
+			"delegation":  delegation,
+		})
+
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), CreateMailboxDelegationIDString(localPart, domainName, delegation))...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("delegation"), delegation)...)
+		return
+	}
+
+	idParts := strings.SplitN(request.ID, "/", 2)
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		response.Diagnostics.Append(MailboxDelegationImportError(request.ID))
+		return
+	}
+
+	mailboxPart := strings.Split(idParts[0], "@")
+	delegation := idParts[1]
+
+	if len(mailboxPart) != 2 || mailboxPart[0] == "" || mailboxPart[1] == "" {
+		response.Diagnostics.Append(MailboxDelegationImportError(request.ID))
+		return
+	}
+
+	localPart := mailboxPart[0]
+	domainName := mailboxPart[1]
+
+	tflog.Trace(ctx, "parsed import ID", map[string]interface{}{
+		"local_part":  localPart,
+		"domain_name": domainName,
+		"delegation":  delegation,
+	})
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("delegation"), delegation)...)
+}
@@ -0,0 +1,265 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestMailboxDelegationResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	provider.NewMailboxDelegationResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestMailboxDelegationResource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		localPart  string
+		domainName string
+		delegation string
+		state      []model.Mailbox
+	}{
+		"add-delegation": {
+			localPart:  "user",
+			domainName: "example.com",
+			delegation: "delegate@example.com",
+			state: []model.Mailbox{
+				{
+					LocalPart:   "user",
+					DomainName:  "example.com",
+					Address:     "user@example.com",
+					Delegations: []string{"existing@example.com"},
+				},
+			},
+		},
+		"existing-delegation": {
+			localPart:  "user",
+			domainName: "example.com",
+			delegation: "existing@example.com",
+			state: []model.Mailbox{
+				{
+					LocalPart:   "user",
+					DomainName:  "example.com",
+					Address:     "user@example.com",
+					Delegations: []string{"existing@example.com"},
+				},
+			},
+		},
+		"idna": {
+			localPart:  "user",
+			domainName: "hoß.de",
+			delegation: "delegate@hoß.de",
+			state: []model.Mailbox{
+				{
+					LocalPart:   "user",
+					DomainName:  "xn--ho-hia.de",
+					Address:     "user@xn--ho-hia.de",
+					Delegations: []string{"existing@xn--ho-hia.de"},
+				},
+			},
+		},
+		"idna-punycode-delegation": {
+			localPart:  "user",
+			domainName: "hoß.de",
+			delegation: "existing@hoß.de",
+			state: []model.Mailbox{
+				{
+					LocalPart:   "user",
+					DomainName:  "xn--ho-hia.de",
+					Address:     "user@xn--ho-hia.de",
+					Delegations: []string{"existing@xn--ho-hia.de"},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Mailboxes: testCase.state}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							resource "migadu_mailbox_delegation" "test" {
+								local_part  = "%s"
+								domain_name = "%s"
+								delegation  = "%s"
+							}
+						`, testCase.localPart, testCase.domainName, testCase.delegation),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_mailbox_delegation.test", "id", fmt.Sprintf("%s@%s/%s", testCase.localPart, testCase.domainName, testCase.delegation)),
+							resource.TestCheckResourceAttr("migadu_mailbox_delegation.test", "local_part", testCase.localPart),
+							resource.TestCheckResourceAttr("migadu_mailbox_delegation.test", "domain_name", testCase.domainName),
+							resource.TestCheckResourceAttr("migadu_mailbox_delegation.test", "delegation", testCase.delegation),
+						),
+					},
+					{
+						ResourceName:      "migadu_mailbox_delegation.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestMailboxDelegationResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{
+		Mailboxes: []model.Mailbox{
+			{
+				LocalPart:  "test",
+				DomainName: "example.com",
+				Address:    "test@example.com",
+			},
+		},
+	}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_mailbox_delegation" "test" {
+						local_part  = "test"
+						domain_name = "example.com"
+						delegation  = "someone@example.com"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("migadu_mailbox_delegation.test", map[string]knownvalue.Check{
+						"local_part":  knownvalue.StringExact("test"),
+						"domain_name": knownvalue.StringExact("example.com"),
+						"delegation":  knownvalue.StringExact("someone@example.com"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "migadu_mailbox_delegation.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestMailboxDelegationResource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetMailbox: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetMailbox: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							resource "migadu_mailbox_delegation" "test" {
+								local_part  = "user"
+								domain_name = "example.com"
+								delegation  = "someone@example.com"
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestMailboxDelegationResource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"empty-domain-name": {
+			Configuration: `
+				domain_name = ""
+				local_part  = "user"
+				delegation  = "someone@example.com"
+			`,
+			ErrorRegex: "Attribute domain_name string length must be at least 1",
+		},
+		"empty-local-part": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = ""
+				delegation  = "someone@example.com"
+			`,
+			ErrorRegex: "Attribute local_part string length must be at least 1",
+		},
+		"invalid-delegation": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "user"
+				delegation  = "someone"
+			`,
+			ErrorRegex: "An email must match the format 'local_part@domain'",
+		},
+		"missing-delegation": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "user"
+			`,
+			ErrorRegex: `The argument "delegation" is required, but no definition was found`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							resource "migadu_mailbox_delegation" "test" {
+								%s
+							}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+// mailboxForwardingAPIAttributes maps API error fields to the attributes of the mailbox forwarding resource.
+var mailboxForwardingAPIAttributes = map[string]string{
+	"address":            "destination",
+	"is_active":          "is_active",
+	"expires_on":         "expires_on",
+	"remove_upon_expiry": "remove_upon_expiry",
+}
+
+func CreateMailboxForwardingID(localPart types.String, domainName custom_types.DomainNameValue, destination custom_types.EmailAddressValue) string {
+	return CreateMailboxForwardingIDString(localPart.ValueString(), domainName.ValueString(), destination.ValueString())
+}
+
+func CreateMailboxForwardingIDString(localPart, domainName, destination string) string {
+	return fmt.Sprintf("%s@%s/%s", localPart, domainName, destination)
+}
+
+func MailboxForwardingConfirmationStatus(forwarding *model.Forwarding) string {
+	if forwarding.BlockedAt != "" {
+		return "blocked"
+	}
+	if forwarding.ConfirmedAt != "" {
+		return "confirmed"
+	}
+	return "pending"
+}
+
+func MailboxForwardingCreateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Creating Mailbox Forwarding", err, mailboxForwardingAPIAttributes)
+}
+
+func MailboxForwardingReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Mailbox Forwarding", err, mailboxForwardingAPIAttributes)
+}
+
+func MailboxForwardingUpdateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Updating Mailbox Forwarding", err, mailboxForwardingAPIAttributes)
+}
+
+func MailboxForwardingDeleteError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Deleting Mailbox Forwarding", err, mailboxForwardingAPIAttributes)
+}
+
+func MailboxForwardingImportError(id string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Mailbox Forwarding",
+		standardImportErrorDetail("local_part@domain_name/destination", id),
+	)
+}
+
+func MailboxForwardingImportIdentityError() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Mailbox Forwarding",
+		standardImportIdentityErrorDetail("local_part", "domain_name", "destination"),
+	)
+}
@@ -0,0 +1,364 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+	"net/http"
+	"strings"
+)
+
+var (
+	_ resource.Resource                = (*MailboxForwardingResource)(nil)
+	_ resource.ResourceWithConfigure   = (*MailboxForwardingResource)(nil)
+	_ resource.ResourceWithImportState = (*MailboxForwardingResource)(nil)
+	_ resource.ResourceWithIdentity    = (*MailboxForwardingResource)(nil)
+)
+
+func NewMailboxForwardingResource() resource.Resource {
+	return &MailboxForwardingResource{}
+}
+
+type MailboxForwardingResource struct {
+	MigaduClient *client.MigaduClient
+}
+
+type MailboxForwardingResourceModel struct {
+	ID                 types.String                   `tfsdk:"id"`
+	LocalPart          types.String                   `tfsdk:"local_part"`
+	DomainName         custom_types.DomainNameValue   `tfsdk:"domain_name"`
+	Destination        custom_types.EmailAddressValue `tfsdk:"destination"`
+	ConfirmationStatus types.String                   `tfsdk:"confirmation_status"`
+	IsActive           types.Bool                     `tfsdk:"is_active"`
+	ExpiresOn          types.String                   `tfsdk:"expires_on"`
+	RemoveUponExpiry   types.Bool                     `tfsdk:"remove_upon_expiry"`
+}
+
+type MailboxForwardingResourceIdentityModel struct {
+	LocalPart   types.String `tfsdk:"local_part"`
+	DomainName  types.String `tfsdk:"domain_name"`
+	Destination types.String `tfsdk:"destination"`
+}
+
+func (r *MailboxForwardingResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_mailbox_forwarding"
+}
+
+func (r *MailboxForwardingResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Provides a forwarding of an existing mailbox to an external address.",
+		MarkdownDescription: "Provides a forwarding of an existing mailbox to an external address.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Contains the value 'local_part@domain_name/destination'.",
+				MarkdownDescription: "Contains the value `local_part@domain_name/destination`.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"local_part": schema.StringAttribute{
+				Description:         "The local part of the mailbox that owns the forwarding.",
+				MarkdownDescription: "The local part of the mailbox that owns the forwarding.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain name of the mailbox that owns the forwarding.",
+				MarkdownDescription: "The domain name of the mailbox that owns the forwarding.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"destination": schema.StringAttribute{
+				Description:         "The email address emails are forwarded to.",
+				MarkdownDescription: "The email address emails are forwarded to.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.EmailAddressType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"confirmation_status": schema.StringAttribute{
+				Description:         "The confirmation status of the forwarding. Migadu sends a confirmation request to the destination which must be accepted before emails are forwarded. One of 'pending', 'confirmed', or 'blocked'.",
+				MarkdownDescription: "The confirmation status of the forwarding. Migadu sends a confirmation request to the destination which must be accepted before emails are forwarded. One of `pending`, `confirmed`, or `blocked`.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+			},
+			"is_active": schema.BoolAttribute{
+				Description:         "Whether the forwarding is active.",
+				MarkdownDescription: "Whether the forwarding is active.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+			},
+			"expires_on": schema.StringAttribute{
+				Description:         "The expiration date of the forwarding.",
+				MarkdownDescription: "The expiration date of the forwarding.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+			},
+			"remove_upon_expiry": schema.BoolAttribute{
+				Description:         "Whether the forwarding is removed once it expires.",
+				MarkdownDescription: "Whether the forwarding is removed once it expires.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *MailboxForwardingResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the mailbox that owns the forwarding.",
+				RequiredForImport: true,
+			},
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the mailbox that owns the forwarding.",
+				RequiredForImport: true,
+			},
+			"destination": identityschema.StringAttribute{
+				Description:       "The email address emails are forwarded to.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *MailboxForwardingResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if providerData, ok := request.ProviderData.(*MigaduProviderData); ok {
+		r.MigaduClient = providerData.Client
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.MigaduProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (r *MailboxForwardingResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var plan MailboxForwardingResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	forwarding := &model.Forwarding{
+		Address:          plan.Destination.ValueString(),
+		IsActive:         plan.IsActive.ValueBool(),
+		ExpiresOn:        plan.ExpiresOn.ValueString(),
+		RemoveUponExpiry: plan.RemoveUponExpiry.ValueBool(),
+	}
+
+	createdForwarding, err := r.MigaduClient.CreateForwarding(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), forwarding)
+	if err != nil {
+		response.Diagnostics.Append(MailboxForwardingCreateError(ctx, err)...)
+		return
+	}
+
+	plan.ID = types.StringValue(CreateMailboxForwardingID(plan.LocalPart, plan.DomainName, plan.Destination))
+	plan.ConfirmationStatus = types.StringValue(MailboxForwardingConfirmationStatus(createdForwarding))
+	plan.IsActive = types.BoolValue(createdForwarding.IsActive)
+	plan.ExpiresOn = types.StringValue(createdForwarding.ExpiresOn)
+	plan.RemoveUponExpiry = types.BoolValue(createdForwarding.RemoveUponExpiry)
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newMailboxForwardingResourceIdentityModel(&plan))...)
+}
+
+func (r *MailboxForwardingResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state MailboxForwardingResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	forwarding, err := r.MigaduClient.GetForwarding(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString(), state.Destination.ValueString())
+	if err != nil {
+		var requestError *client.RequestError
+		if errors.As(err, &requestError) {
+			if requestError.StatusCode == http.StatusNotFound {
+				response.State.RemoveResource(ctx)
+				return
+			}
+		}
+		response.Diagnostics.Append(MailboxForwardingReadError(ctx, err)...)
+		return
+	}
+
+	state.ID = types.StringValue(CreateMailboxForwardingID(state.LocalPart, state.DomainName, state.Destination))
+	state.ConfirmationStatus = types.StringValue(MailboxForwardingConfirmationStatus(forwarding))
+	state.IsActive = types.BoolValue(forwarding.IsActive)
+	state.ExpiresOn = types.StringValue(forwarding.ExpiresOn)
+	state.RemoveUponExpiry = types.BoolValue(forwarding.RemoveUponExpiry)
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newMailboxForwardingResourceIdentityModel(&state))...)
+}
+
+func newMailboxForwardingResourceIdentityModel(state *MailboxForwardingResourceModel) MailboxForwardingResourceIdentityModel {
+	return MailboxForwardingResourceIdentityModel{
+		LocalPart:   types.StringValue(state.LocalPart.ValueString()),
+		DomainName:  types.StringValue(state.DomainName.ValueString()),
+		Destination: types.StringValue(state.Destination.ValueString()),
+	}
+}
+
+func (r *MailboxForwardingResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var plan MailboxForwardingResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	forwarding := &model.Forwarding{
+		IsActive:         plan.IsActive.ValueBool(),
+		ExpiresOn:        plan.ExpiresOn.ValueString(),
+		RemoveUponExpiry: plan.RemoveUponExpiry.ValueBool(),
+	}
+
+	updatedForwarding, err := r.MigaduClient.UpdateForwarding(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), plan.Destination.ValueString(), forwarding)
+	if err != nil {
+		response.Diagnostics.Append(MailboxForwardingUpdateError(ctx, err)...)
+		return
+	}
+
+	plan.ID = types.StringValue(CreateMailboxForwardingID(plan.LocalPart, plan.DomainName, plan.Destination))
+	plan.ConfirmationStatus = types.StringValue(MailboxForwardingConfirmationStatus(updatedForwarding))
+	plan.IsActive = types.BoolValue(updatedForwarding.IsActive)
+	plan.ExpiresOn = types.StringValue(updatedForwarding.ExpiresOn)
+	plan.RemoveUponExpiry = types.BoolValue(updatedForwarding.RemoveUponExpiry)
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+}
+
+func (r *MailboxForwardingResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state MailboxForwardingResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.MigaduClient.DeleteForwarding(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString(), state.Destination.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(MailboxForwardingDeleteError(ctx, err)...)
+		return
+	}
+}
+
+func (r *MailboxForwardingResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID == "" && request.Identity != nil && !request.Identity.Raw.IsNull() {
+		var resourceIdentity MailboxForwardingResourceIdentityModel
+		response.Diagnostics.Append(request.Identity.Get(ctx, &resourceIdentity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		localPart := resourceIdentity.LocalPart.ValueString()
+		domainName := resourceIdentity.DomainName.ValueString()
+		destination := resourceIdentity.Destination.ValueString()
+
+		if localPart == "" || domainName == "" || destination == "" {
+			response.Diagnostics.Append(MailboxForwardingImportIdentityError())
+			return
+		}
+
+		tflog.Trace(ctx, "read import identity", map[string]interface{}{
+			"local_part":  localPart,
+			"domain_name": domainName,
+			"destination": destination,
+		})
+
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), CreateMailboxForwardingIDString(localPart, domainName, destination))...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("destination"), destination)...)
+		return
+	}
+
+	idParts := strings.SplitN(request.ID, "/", 2)
+
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		response.Diagnostics.Append(MailboxForwardingImportError(request.ID))
+		return
+	}
+
+	mailboxPart := strings.Split(idParts[0], "@")
+	destination := idParts[1]
+
+	if len(mailboxPart) != 2 || mailboxPart[0] == "" || mailboxPart[1] == "" {
+		response.Diagnostics.Append(MailboxForwardingImportError(request.ID))
+		return
+	}
+
+	localPart := mailboxPart[0]
+	domainName := mailboxPart[1]
+
+	tflog.Trace(ctx, "parsed import ID", map[string]interface{}{
+		"local_part":  localPart,
+		"domain_name": domainName,
+		"destination": destination,
+	})
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("destination"), destination)...)
+}
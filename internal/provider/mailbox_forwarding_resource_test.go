@@ -0,0 +1,337 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestMailboxForwardingResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	provider.NewMailboxForwardingResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestMailboxForwardingResource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		domainName string
+		localPart  string
+		state      []simulator.Forwarding
+		create     ResourceTestStep[model.Forwarding]
+		update     ResourceTestStep[model.Forwarding]
+	}{
+		"single": {
+			domainName: "example.com",
+			localPart:  "test",
+			state:      []simulator.Forwarding{},
+			create: ResourceTestStep[model.Forwarding]{
+				Send: model.Forwarding{
+					Address:  "someone@example.org",
+					IsActive: true,
+				},
+				Want: model.Forwarding{
+					Address:  "someone@example.org",
+					IsActive: true,
+				},
+			},
+			update: ResourceTestStep[model.Forwarding]{
+				Send: model.Forwarding{
+					Address:  "someone@example.org",
+					IsActive: false,
+				},
+				Want: model.Forwarding{
+					Address:  "someone@example.org",
+					IsActive: false,
+				},
+			},
+		},
+		"multiple": {
+			domainName: "example.com",
+			localPart:  "test",
+			state: []simulator.Forwarding{
+				{
+					DomainName: "example.com",
+					LocalPart:  "test",
+					Forwarding: model.Forwarding{
+						Address:     "other@example.org",
+						IsActive:    true,
+						ConfirmedAt: "2024-01-01T00:00:00Z",
+					},
+				},
+			},
+			create: ResourceTestStep[model.Forwarding]{
+				Send: model.Forwarding{
+					Address:  "someone@example.org",
+					IsActive: true,
+				},
+				Want: model.Forwarding{
+					Address:  "someone@example.org",
+					IsActive: true,
+				},
+			},
+			update: ResourceTestStep[model.Forwarding]{
+				Send: model.Forwarding{
+					Address:          "someone@example.org",
+					IsActive:         true,
+					ExpiresOn:        "2030-12-31",
+					RemoveUponExpiry: true,
+				},
+				Want: model.Forwarding{
+					Address:          "someone@example.org",
+					IsActive:         true,
+					ExpiresOn:        "2030-12-31",
+					RemoveUponExpiry: true,
+				},
+			},
+		},
+		"idna": {
+			domainName: "hoß.de",
+			localPart:  "test",
+			state:      []simulator.Forwarding{},
+			create: ResourceTestStep[model.Forwarding]{
+				Send: model.Forwarding{
+					Address:  "someone@bücher.example",
+					IsActive: true,
+				},
+				Want: model.Forwarding{
+					Address:  "someone@bücher.example",
+					IsActive: true,
+				},
+			},
+			update: ResourceTestStep[model.Forwarding]{
+				Send: model.Forwarding{
+					Address:  "someone@bücher.example",
+					IsActive: false,
+				},
+				Want: model.Forwarding{
+					Address:  "someone@bücher.example",
+					IsActive: false,
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Forwardings: testCase.state}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							resource "migadu_mailbox_forwarding" "test" {
+								domain_name        = "%s"
+								local_part         = "%s"
+								destination        = "%s"
+								is_active          = %t
+								remove_upon_expiry = %t
+							}
+						`, testCase.domainName, testCase.localPart, testCase.create.Send.Address, testCase.create.Send.IsActive, testCase.create.Send.RemoveUponExpiry),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "id", fmt.Sprintf("%s@%s/%s", testCase.localPart, testCase.domainName, testCase.create.Want.Address)),
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "domain_name", testCase.domainName),
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "local_part", testCase.localPart),
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "destination", testCase.create.Want.Address),
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "is_active", fmt.Sprintf("%v", testCase.create.Want.IsActive)),
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "remove_upon_expiry", fmt.Sprintf("%v", testCase.create.Want.RemoveUponExpiry)),
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "confirmation_status", "pending"),
+						),
+					},
+					{
+						ResourceName:      "migadu_mailbox_forwarding.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							resource "migadu_mailbox_forwarding" "test" {
+								domain_name        = "%s"
+								local_part         = "%s"
+								destination        = "%s"
+								is_active          = %t
+								expires_on         = "%s"
+								remove_upon_expiry = %t
+							}
+						`, testCase.domainName, testCase.localPart, testCase.update.Send.Address, testCase.update.Send.IsActive, testCase.update.Send.ExpiresOn, testCase.update.Send.RemoveUponExpiry),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "id", fmt.Sprintf("%s@%s/%s", testCase.localPart, testCase.domainName, testCase.update.Want.Address)),
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "destination", testCase.update.Want.Address),
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "is_active", fmt.Sprintf("%v", testCase.update.Want.IsActive)),
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "expires_on", testCase.update.Want.ExpiresOn),
+							resource.TestCheckResourceAttr("migadu_mailbox_forwarding.test", "remove_upon_expiry", fmt.Sprintf("%v", testCase.update.Want.RemoveUponExpiry)),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestMailboxForwardingResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_mailbox_forwarding" "test" {
+						local_part  = "test"
+						domain_name = "example.com"
+						destination = "someone@example.org"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("migadu_mailbox_forwarding.test", map[string]knownvalue.Check{
+						"local_part":  knownvalue.StringExact("test"),
+						"domain_name": knownvalue.StringExact("example.com"),
+						"destination": knownvalue.StringExact("someone@example.org"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "migadu_mailbox_forwarding.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestMailboxForwardingResource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "CreateForwarding: status: 404",
+		},
+		"error-409": {
+			StatusCode: http.StatusConflict,
+			ErrorRegex: "CreateForwarding: status: 409",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "CreateForwarding: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							resource "migadu_mailbox_forwarding" "test" {
+								domain_name = "example.com"
+								local_part  = "test"
+								destination = "someone@example.org"
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestMailboxForwardingResource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"empty-domain-name": {
+			Configuration: `
+				domain_name = ""
+				local_part  = "test"
+				destination = "someone@example.org"
+			`,
+			ErrorRegex: "Attribute domain_name string length must be at least 1",
+		},
+		"empty-local-part": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = ""
+				destination = "someone@example.org"
+			`,
+			ErrorRegex: "Attribute local_part string length must be at least 1",
+		},
+		"empty-destination": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "test"
+				destination = ""
+			`,
+			ErrorRegex: "Attribute destination string length must be at least 1",
+		},
+		"missing-domain-name": {
+			Configuration: `
+				local_part  = "test"
+				destination = "someone@example.org"
+			`,
+			ErrorRegex: `The argument "domain_name" is required, but no definition was found`,
+		},
+		"missing-local-part": {
+			Configuration: `
+				domain_name = "example.com"
+				destination = "someone@example.org"
+			`,
+			ErrorRegex: `The argument "local_part" is required, but no definition was found`,
+		},
+		"missing-destination": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "test"
+			`,
+			ErrorRegex: `The argument "destination" is required, but no definition was found`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							resource "migadu_mailbox_forwarding" "test" {
+								%s
+							}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+var (
+	_ datasource.DataSource              = (*MailboxForwardingsDataSource)(nil)
+	_ datasource.DataSourceWithConfigure = (*MailboxForwardingsDataSource)(nil)
+)
+
+func NewMailboxForwardingsDataSource() datasource.DataSource {
+	return &MailboxForwardingsDataSource{}
+}
+
+type MailboxForwardingsDataSource struct {
+	MigaduClient *client.MigaduClient
+}
+
+type MailboxForwardingsDataSourceModel struct {
+	ID          custom_types.EmailAddressValue `tfsdk:"id"`
+	LocalPart   types.String                   `tfsdk:"local_part"`
+	DomainName  custom_types.DomainNameValue   `tfsdk:"domain_name"`
+	Forwardings []MailboxForwardingModel       `tfsdk:"forwardings"`
+}
+
+type MailboxForwardingModel struct {
+	Address            custom_types.EmailAddressValue `tfsdk:"address"`
+	ConfirmationStatus types.String                   `tfsdk:"confirmation_status"`
+	IsActive           types.Bool                     `tfsdk:"is_active"`
+	ExpiresOn          types.String                   `tfsdk:"expires_on"`
+	RemoveUponExpiry   types.Bool                     `tfsdk:"remove_upon_expiry"`
+}
+
+func (d *MailboxForwardingsDataSource) Metadata(_ context.Context, request datasource.MetadataRequest, response *datasource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_mailbox_forwardings"
+}
+
+func (d *MailboxForwardingsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, response *datasource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Get information about all forwardings of a mailbox.",
+		MarkdownDescription: "Get information about all forwardings of a mailbox.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Contains the value 'local_part@domain_name'.",
+				MarkdownDescription: "Contains the value `local_part@domain_name`.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				CustomType:          custom_types.EmailAddressType{},
+			},
+			"local_part": schema.StringAttribute{
+				Description:         "The local part of the mailbox that owns the forwardings.",
+				MarkdownDescription: "The local part of the mailbox that owns the forwardings.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain name of the mailbox that owns the forwardings.",
+				MarkdownDescription: "The domain name of the mailbox that owns the forwardings.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"forwardings": schema.ListNestedAttribute{
+				Description:         "The configured forwardings for the given 'domain_name' and 'local_part'.",
+				MarkdownDescription: "The configured forwardings for the given `domain_name` and `local_part`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Description:         "The email address emails are forwarded to.",
+							MarkdownDescription: "The email address emails are forwarded to.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+							CustomType:          custom_types.EmailAddressType{},
+						},
+						"confirmation_status": schema.StringAttribute{
+							Description:         "The confirmation status of the forwarding. One of 'pending', 'confirmed', or 'blocked'.",
+							MarkdownDescription: "The confirmation status of the forwarding. One of `pending`, `confirmed`, or `blocked`.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"is_active": schema.BoolAttribute{
+							Description:         "Whether the forwarding is active.",
+							MarkdownDescription: "Whether the forwarding is active.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"expires_on": schema.StringAttribute{
+							Description:         "The expiration date of the forwarding.",
+							MarkdownDescription: "The expiration date of the forwarding.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"remove_upon_expiry": schema.BoolAttribute{
+							Description:         "Whether the forwarding is removed once it expires.",
+							MarkdownDescription: "Whether the forwarding is removed once it expires.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *MailboxForwardingsDataSource) Configure(_ context.Context, request datasource.ConfigureRequest, response *datasource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
+		d.MigaduClient = migaduClient
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (d *MailboxForwardingsDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var data MailboxForwardingsDataSourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	forwardings, err := d.MigaduClient.GetForwardings(ctx, data.DomainName.ValueString(), data.LocalPart.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(MailboxForwardingReadError(ctx, err)...)
+		return
+	}
+
+	for _, forwarding := range forwardings.Forwardings {
+		model := MailboxForwardingModel{
+			Address:            custom_types.NewEmailAddressValue(forwarding.Address),
+			ConfirmationStatus: types.StringValue(MailboxForwardingConfirmationStatus(&forwarding)),
+			IsActive:           types.BoolValue(forwarding.IsActive),
+			ExpiresOn:          types.StringValue(forwarding.ExpiresOn),
+			RemoveUponExpiry:   types.BoolValue(forwarding.RemoveUponExpiry),
+		}
+		data.Forwardings = append(data.Forwardings, model)
+	}
+
+	data.ID = custom_types.NewEmailAddressValue(fmt.Sprintf("%s@%s", data.LocalPart.ValueString(), data.DomainName.ValueString()))
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
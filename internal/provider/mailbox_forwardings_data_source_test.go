@@ -0,0 +1,250 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwdatasource "github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestMailboxForwardingsDataSource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwdatasource.SchemaRequest{}
+	schemaResponse := &fwdatasource.SchemaResponse{}
+
+	provider.NewMailboxForwardingsDataSource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestMailboxForwardingsDataSource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		domainName string
+		localPart  string
+		state      []simulator.Forwarding
+		want       model.Forwardings
+	}{
+		"empty": {
+			domainName: "example.com",
+			localPart:  "test",
+			state:      []simulator.Forwarding{},
+			want:       model.Forwardings{},
+		},
+		"single": {
+			domainName: "example.com",
+			localPart:  "test",
+			state: []simulator.Forwarding{
+				{
+					DomainName: "example.com",
+					LocalPart:  "test",
+					Forwarding: model.Forwarding{
+						Address:     "someone@example.org",
+						IsActive:    true,
+						ConfirmedAt: "2024-01-01T00:00:00Z",
+					},
+				},
+			},
+			want: model.Forwardings{
+				Forwardings: []model.Forwarding{
+					{
+						Address:     "someone@example.org",
+						IsActive:    true,
+						ConfirmedAt: "2024-01-01T00:00:00Z",
+					},
+				},
+			},
+		},
+		"filtered": {
+			domainName: "example.com",
+			localPart:  "test",
+			state: []simulator.Forwarding{
+				{
+					DomainName: "example.com",
+					LocalPart:  "test",
+					Forwarding: model.Forwarding{
+						Address:     "someone@example.org",
+						IsActive:    true,
+						ConfirmedAt: "2024-01-01T00:00:00Z",
+					},
+				},
+				{
+					DomainName: "example.com",
+					LocalPart:  "other",
+					Forwarding: model.Forwarding{
+						Address:  "other@example.org",
+						IsActive: true,
+					},
+				},
+			},
+			want: model.Forwardings{
+				Forwardings: []model.Forwarding{
+					{
+						Address:     "someone@example.org",
+						IsActive:    true,
+						ConfirmedAt: "2024-01-01T00:00:00Z",
+					},
+				},
+			},
+		},
+		"idna": {
+			domainName: "hoß.de",
+			localPart:  "test",
+			state: []simulator.Forwarding{
+				{
+					DomainName: "xn--ho-hia.de",
+					LocalPart:  "test",
+					Forwarding: model.Forwarding{
+						Address:     "someone@example.org",
+						IsActive:    true,
+						ConfirmedAt: "2024-01-01T00:00:00Z",
+					},
+				},
+			},
+			want: model.Forwardings{
+				Forwardings: []model.Forwarding{
+					{
+						Address:     "someone@example.org",
+						IsActive:    true,
+						ConfirmedAt: "2024-01-01T00:00:00Z",
+					},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Forwardings: testCase.state}))
+			defer server.Close()
+
+			checks := []resource.TestCheckFunc{
+				resource.TestCheckResourceAttr("data.migadu_mailbox_forwardings.test", "id", fmt.Sprintf("%s@%s", testCase.localPart, testCase.domainName)),
+				resource.TestCheckResourceAttr("data.migadu_mailbox_forwardings.test", "domain_name", testCase.domainName),
+				resource.TestCheckResourceAttr("data.migadu_mailbox_forwardings.test", "local_part", testCase.localPart),
+				resource.TestCheckResourceAttr("data.migadu_mailbox_forwardings.test", "forwardings.#", fmt.Sprintf("%v", len(testCase.want.Forwardings))),
+			}
+			for index, forwarding := range testCase.want.Forwardings {
+				checks = append(checks,
+					resource.TestCheckResourceAttr("data.migadu_mailbox_forwardings.test", fmt.Sprintf("forwardings.%d.address", index), forwarding.Address),
+					resource.TestCheckResourceAttr("data.migadu_mailbox_forwardings.test", fmt.Sprintf("forwardings.%d.is_active", index), fmt.Sprintf("%v", forwarding.IsActive)),
+					resource.TestCheckResourceAttr("data.migadu_mailbox_forwardings.test", fmt.Sprintf("forwardings.%d.confirmation_status", index), "confirmed"),
+				)
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							data "migadu_mailbox_forwardings" "test" {
+								domain_name = "%s"
+								local_part  = "%s"
+							}
+						`, testCase.domainName, testCase.localPart),
+						Check: resource.ComposeAggregateTestCheckFunc(checks...),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestMailboxForwardingsDataSource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetForwardings: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetForwardings: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							data "migadu_mailbox_forwardings" "test" {
+								domain_name = "example.com"
+								local_part  = "test"
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestMailboxForwardingsDataSource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"empty-domain-name": {
+			Configuration: `
+				domain_name = ""
+				local_part  = "test"
+			`,
+			ErrorRegex: "Attribute domain_name string length must be at least 1",
+		},
+		"empty-local-part": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = ""
+			`,
+			ErrorRegex: "Attribute local_part string length must be at least 1",
+		},
+		"missing-domain-name": {
+			Configuration: `
+				local_part = "test"
+			`,
+			ErrorRegex: `The argument "domain_name" is required, but no definition was found`,
+		},
+		"missing-local-part": {
+			Configuration: `
+				domain_name = "example.com"
+			`,
+			ErrorRegex: `The argument "local_part" is required, but no definition was found`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							data "migadu_mailbox_forwardings" "test" {
+								%s
+							}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+const (
+	mailboxListSenderDenylist    = "sender_denylist"
+	mailboxListSenderAllowlist   = "sender_allowlist"
+	mailboxListRecipientDenylist = "recipient_denylist"
+	mailboxListDelegations       = "delegations"
+)
+
+// mailboxListEntryLists contains the lists that can be managed by the migadu_mailbox_list_entry resource.
+var mailboxListEntryLists = []string{
+	mailboxListSenderDenylist,
+	mailboxListSenderAllowlist,
+	mailboxListRecipientDenylist,
+}
+
+// mailboxListEntryAPIAttributes maps API error fields to the attributes of the mailbox list entry resource.
+var mailboxListEntryAPIAttributes = map[string]string{
+	"sender_denylist":    "address",
+	"sender_allowlist":   "address",
+	"recipient_denylist": "address",
+}
+
+// mailboxList returns a pointer to the list of the given mailbox that matches the given attribute name of the mailbox resource.
+func mailboxList(mailbox *model.Mailbox, list string) *[]string {
+	switch list {
+	case mailboxListSenderDenylist:
+		return &mailbox.SenderDenyList
+	case mailboxListSenderAllowlist:
+		return &mailbox.SenderAllowList
+	case mailboxListRecipientDenylist:
+		return &mailbox.RecipientDenyList
+	case mailboxListDelegations:
+		return &mailbox.Delegations
+	}
+	return nil
+}
+
+func CreateMailboxListEntryID(localPart types.String, domainName custom_types.DomainNameValue, list types.String, address custom_types.EmailAddressValue) string {
+	return CreateMailboxListEntryIDString(localPart.ValueString(), domainName.ValueString(), list.ValueString(), address.ValueString())
+}
+
+func CreateMailboxListEntryIDString(localPart, domainName, list, address string) string {
+	return fmt.Sprintf("%s@%s/%s/%s", localPart, domainName, list, address)
+}
+
+func MailboxListEntryCreateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Creating Mailbox List Entry", err, mailboxListEntryAPIAttributes)
+}
+
+func MailboxListEntryReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Mailbox List Entry", err, mailboxListEntryAPIAttributes)
+}
+
+func MailboxListEntryDeleteError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Deleting Mailbox List Entry", err, mailboxListEntryAPIAttributes)
+}
+
+func MailboxListEntryImportError(id string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Mailbox List Entry",
+		standardImportErrorDetail("local_part@domain_name/list/address", id),
+	)
+}
+
+func MailboxListEntryImportIdentityError() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Mailbox List Entry",
+		standardImportIdentityErrorDetail("local_part", "domain_name", "list", "address"),
+	)
+}
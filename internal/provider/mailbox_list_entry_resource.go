@@ -0,0 +1,384 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+var (
+	_ resource.Resource                = (*MailboxListEntryResource)(nil)
+	_ resource.ResourceWithConfigure   = (*MailboxListEntryResource)(nil)
+	_ resource.ResourceWithImportState = (*MailboxListEntryResource)(nil)
+	_ resource.ResourceWithIdentity    = (*MailboxListEntryResource)(nil)
+)
+
+func NewMailboxListEntryResource() resource.Resource {
+	return &MailboxListEntryResource{}
+}
+
+type MailboxListEntryResource struct {
+	MigaduClient *client.MigaduClient
+	ReadCache    *ReadCache
+}
+
+type MailboxListEntryResourceModel struct {
+	ID         types.String                   `tfsdk:"id"`
+	LocalPart  types.String                   `tfsdk:"local_part"`
+	DomainName custom_types.DomainNameValue   `tfsdk:"domain_name"`
+	List       types.String                   `tfsdk:"list"`
+	Address    custom_types.EmailAddressValue `tfsdk:"address"`
+}
+
+type MailboxListEntryResourceIdentityModel struct {
+	LocalPart  types.String `tfsdk:"local_part"`
+	DomainName types.String `tfsdk:"domain_name"`
+	List       types.String `tfsdk:"list"`
+	Address    types.String `tfsdk:"address"`
+}
+
+func (r *MailboxListEntryResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_mailbox_list_entry"
+}
+
+func (r *MailboxListEntryResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Provides a single entry in the sender denylist, sender allowlist, or recipient denylist of an existing mailbox. Other entries of the list are left untouched. Leave the matching attribute of the 'migadu_mailbox' resource of the same mailbox unset in order to avoid conflicting changes.",
+		MarkdownDescription: "Provides a single entry in the sender denylist, sender allowlist, or recipient denylist of an existing mailbox. Other entries of the list are left untouched. Leave the matching attribute of the `migadu_mailbox` resource of the same mailbox unset in order to avoid conflicting changes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Contains the value 'local_part@domain_name/list/address'.",
+				MarkdownDescription: "Contains the value `local_part@domain_name/list/address`.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"local_part": schema.StringAttribute{
+				Description:         "The local part of the mailbox.",
+				MarkdownDescription: "The local part of the mailbox.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain name of the mailbox.",
+				MarkdownDescription: "The domain name of the mailbox.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"list": schema.StringAttribute{
+				Description:         "The list of the mailbox to add the address to. Must be one of 'sender_denylist', 'sender_allowlist', or 'recipient_denylist'.",
+				MarkdownDescription: "The list of the mailbox to add the address to. Must be one of `sender_denylist`, `sender_allowlist`, or `recipient_denylist`.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				Validators: []validator.String{
+					stringvalidator.OneOf(mailboxListEntryLists...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"address": schema.StringAttribute{
+				Description:         "The email address to add to the list of the mailbox.",
+				MarkdownDescription: "The email address to add to the list of the mailbox.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.EmailAddressType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *MailboxListEntryResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the mailbox.",
+				RequiredForImport: true,
+			},
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the mailbox.",
+				RequiredForImport: true,
+			},
+			"list": identityschema.StringAttribute{
+				Description:       "The list of the mailbox to add the address to.",
+				RequiredForImport: true,
+			},
+			"address": identityschema.StringAttribute{
+				Description:       "The email address to add to the list of the mailbox.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *MailboxListEntryResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if providerData, ok := request.ProviderData.(*MigaduProviderData); ok {
+		r.MigaduClient = providerData.Client
+		r.ReadCache = providerData.ReadCache
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.MigaduProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (r *MailboxListEntryResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var plan MailboxListEntryResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	unlock := lockObject("mailbox", plan.LocalPart.ValueString(), plan.DomainName.ValueString())
+	defer unlock()
+
+	mailbox, err := r.MigaduClient.GetMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString())
+	if err != nil {
+		response.Diagnostics.Append(MailboxListEntryCreateError(ctx, err)...)
+		return
+	}
+
+	entries := mailboxList(mailbox, plan.List.ValueString())
+	list, diags := custom_types.NewEmailAddressSetValueFrom(ctx, *entries)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if !list.Contains(ctx, plan.Address) {
+		*entries = append(*entries, plan.Address.ValueString())
+
+		_, err = r.MigaduClient.UpdateMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), mailbox)
+		if err != nil {
+			response.Diagnostics.Append(MailboxListEntryCreateError(ctx, err)...)
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(CreateMailboxListEntryID(plan.LocalPart, plan.DomainName, plan.List, plan.Address))
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newMailboxListEntryResourceIdentityModel(&plan))...)
+}
+
+func (r *MailboxListEntryResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state MailboxListEntryResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	mailbox, err := r.ReadCache.GetMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
+	if err != nil {
+		var requestError *client.RequestError
+		if errors.As(err, &requestError) {
+			if requestError.StatusCode == http.StatusNotFound {
+				response.State.RemoveResource(ctx)
+				return
+			}
+		}
+		response.Diagnostics.Append(MailboxListEntryReadError(ctx, err)...)
+		return
+	}
+
+	list, diags := custom_types.NewEmailAddressSetValueFrom(ctx, *mailboxList(mailbox, state.List.ValueString()))
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if !list.Contains(ctx, state.Address) {
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	state.ID = types.StringValue(CreateMailboxListEntryID(state.LocalPart, state.DomainName, state.List, state.Address))
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newMailboxListEntryResourceIdentityModel(&state))...)
+}
+
+func newMailboxListEntryResourceIdentityModel(state *MailboxListEntryResourceModel) MailboxListEntryResourceIdentityModel {
+	return MailboxListEntryResourceIdentityModel{
+		LocalPart:  types.StringValue(state.LocalPart.ValueString()),
+		DomainName: types.StringValue(state.DomainName.ValueString()),
+		List:       types.StringValue(state.List.ValueString()),
+		Address:    types.StringValue(state.Address.ValueString()),
+	}
+}
+
+func (r *MailboxListEntryResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var plan MailboxListEntryResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(CreateMailboxListEntryID(plan.LocalPart, plan.DomainName, plan.List, plan.Address))
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+}
+
+func (r *MailboxListEntryResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state MailboxListEntryResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(state.DomainName.ValueString())
+
+	unlock := lockObject("mailbox", state.LocalPart.ValueString(), state.DomainName.ValueString())
+	defer unlock()
+
+	mailbox, err := r.MigaduClient.GetMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
+	if err != nil {
+		var requestError *client.RequestError
+		if errors.As(err, &requestError) {
+			if requestError.StatusCode == http.StatusNotFound {
+				return
+			}
+		}
+		response.Diagnostics.Append(MailboxListEntryDeleteError(ctx, err)...)
+		return
+	}
+
+	entries := mailboxList(mailbox, state.List.ValueString())
+	remainingEntries := removeEmailAddress(ctx, *entries, state.Address)
+	if len(remainingEntries) == len(*entries) {
+		return
+	}
+	*entries = remainingEntries
+
+	_, err = r.MigaduClient.UpdateMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString(), mailbox)
+	if err != nil {
+		response.Diagnostics.Append(MailboxListEntryDeleteError(ctx, err)...)
+		return
+	}
+}
+
+func (r *MailboxListEntryResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID == "" && request.Identity != nil && !request.Identity.Raw.IsNull() {
+		var resourceIdentity MailboxListEntryResourceIdentityModel
+		response.Diagnostics.Append(request.Identity.Get(ctx, &resourceIdentity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		localPart := resourceIdentity.LocalPart.ValueString()
+		domainName := resourceIdentity.DomainName.ValueString()
+		list := resourceIdentity.List.ValueString()
+		address := resourceIdentity.Address.ValueString()
+
+		if localPart == "" || domainName == "" || list == "" || address == "" || !slices.Contains(mailboxListEntryLists, list) {
+			response.Diagnostics.Append(MailboxListEntryImportIdentityError())
+			return
+		}
+
+		tflog.Trace(ctx, "read import identity", map[string]interface{}{
+			"local_part":  localPart,
+			"domain_name": domainName,
+			"list":        list,
+			"address":     address,
+		})
+
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), CreateMailboxListEntryIDString(localPart, domainName, list, address))...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("list"), list)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("address"), address)...)
+		return
+	}
+
+	idParts := strings.SplitN(request.ID, "/", 3)
+
+	if len(idParts) != 3 || idParts[0] == "" || idParts[1] == "" || idParts[2] == "" {
+		response.Diagnostics.Append(MailboxListEntryImportError(request.ID))
+		return
+	}
+
+	mailboxPart := strings.Split(idParts[0], "@")
+	list := idParts[1]
+	address := idParts[2]
+
+	if len(mailboxPart) != 2 || mailboxPart[0] == "" || mailboxPart[1] == "" || !slices.Contains(mailboxListEntryLists, list) {
+		response.Diagnostics.Append(MailboxListEntryImportError(request.ID))
+		return
+	}
+
+	localPart := mailboxPart[0]
+	domainName := mailboxPart[1]
+
+	tflog.Trace(ctx, "parsed import ID", map[string]interface{}{
+		"local_part":  localPart,
+		"domain_name": domainName,
+		"list":        list,
+		"address":     address,
+	})
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("list"), list)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("address"), address)...)
+}
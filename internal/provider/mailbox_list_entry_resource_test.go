@@ -0,0 +1,396 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestMailboxListEntryResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	provider.NewMailboxListEntryResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestMailboxListEntryResource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		localPart  string
+		domainName string
+		list       string
+		address    string
+		state      []model.Mailbox
+	}{
+		"sender-denylist": {
+			localPart:  "user",
+			domainName: "example.com",
+			list:       "sender_denylist",
+			address:    "spam@example.org",
+			state: []model.Mailbox{
+				{
+					LocalPart:      "user",
+					DomainName:     "example.com",
+					Address:        "user@example.com",
+					SenderDenyList: []string{"existing@example.org"},
+				},
+			},
+		},
+		"sender-allowlist": {
+			localPart:  "user",
+			domainName: "example.com",
+			list:       "sender_allowlist",
+			address:    "friend@example.org",
+			state: []model.Mailbox{
+				{
+					LocalPart:       "user",
+					DomainName:      "example.com",
+					Address:         "user@example.com",
+					SenderAllowList: []string{"existing@example.org"},
+				},
+			},
+		},
+		"recipient-denylist": {
+			localPart:  "user",
+			domainName: "example.com",
+			list:       "recipient_denylist",
+			address:    "blocked@example.org",
+			state: []model.Mailbox{
+				{
+					LocalPart:  "user",
+					DomainName: "example.com",
+					Address:    "user@example.com",
+				},
+			},
+		},
+		"existing-address": {
+			localPart:  "user",
+			domainName: "example.com",
+			list:       "sender_denylist",
+			address:    "existing@example.org",
+			state: []model.Mailbox{
+				{
+					LocalPart:      "user",
+					DomainName:     "example.com",
+					Address:        "user@example.com",
+					SenderDenyList: []string{"existing@example.org"},
+				},
+			},
+		},
+		"idna": {
+			localPart:  "user",
+			domainName: "hoß.de",
+			list:       "sender_denylist",
+			address:    "spam@hoß.de",
+			state: []model.Mailbox{
+				{
+					LocalPart:      "user",
+					DomainName:     "xn--ho-hia.de",
+					Address:        "user@xn--ho-hia.de",
+					SenderDenyList: []string{"existing@xn--ho-hia.de"},
+				},
+			},
+		},
+		"idna-punycode-address": {
+			localPart:  "user",
+			domainName: "hoß.de",
+			list:       "sender_denylist",
+			address:    "existing@hoß.de",
+			state: []model.Mailbox{
+				{
+					LocalPart:      "user",
+					DomainName:     "xn--ho-hia.de",
+					Address:        "user@xn--ho-hia.de",
+					SenderDenyList: []string{"existing@xn--ho-hia.de"},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Mailboxes: testCase.state}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							resource "migadu_mailbox_list_entry" "test" {
+								local_part  = "%s"
+								domain_name = "%s"
+								list        = "%s"
+								address     = "%s"
+							}
+						`, testCase.localPart, testCase.domainName, testCase.list, testCase.address),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_mailbox_list_entry.test", "id", fmt.Sprintf("%s@%s/%s/%s", testCase.localPart, testCase.domainName, testCase.list, testCase.address)),
+							resource.TestCheckResourceAttr("migadu_mailbox_list_entry.test", "local_part", testCase.localPart),
+							resource.TestCheckResourceAttr("migadu_mailbox_list_entry.test", "domain_name", testCase.domainName),
+							resource.TestCheckResourceAttr("migadu_mailbox_list_entry.test", "list", testCase.list),
+							resource.TestCheckResourceAttr("migadu_mailbox_list_entry.test", "address", testCase.address),
+						),
+					},
+					{
+						ResourceName:      "migadu_mailbox_list_entry.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestMailboxListEntryResource_API_Success_With_Mailbox(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	mailbox := func(name string) string {
+		return fmt.Sprintf(`
+			resource "migadu_mailbox" "test" {
+				local_part  = "test"
+				domain_name = "example.com"
+				password    = "secret"
+				name        = "%s"
+			}
+		`, name)
+	}
+	entries := `
+		resource "migadu_mailbox_list_entry" "denied" {
+			local_part  = migadu_mailbox.test.local_part
+			domain_name = migadu_mailbox.test.domain_name
+			list        = "sender_denylist"
+			address     = "spam@example.org"
+		}
+
+		resource "migadu_mailbox_list_entry" "allowed" {
+			local_part  = migadu_mailbox.test.local_part
+			domain_name = migadu_mailbox.test.domain_name
+			list        = "sender_allowlist"
+			address     = "friend@example.org"
+		}
+
+		resource "migadu_mailbox_delegation" "test" {
+			local_part  = migadu_mailbox.test.local_part
+			domain_name = migadu_mailbox.test.domain_name
+			delegation  = "assistant@example.com"
+		}
+	`
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + mailbox("Some Name") + entries,
+			},
+			{
+				Config: providerConfig(server.URL) + mailbox("Different Name") + entries,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "name", "Different Name"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "sender_denylist.#", "1"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "sender_denylist.0", "spam@example.org"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "sender_allowlist.#", "1"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "sender_allowlist.0", "friend@example.org"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "delegations.#", "1"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "delegations.0", "assistant@example.com"),
+				),
+			},
+			{
+				Config:   providerConfig(server.URL) + mailbox("Different Name") + entries,
+				PlanOnly: true,
+			},
+			{
+				Config: providerConfig(server.URL) + mailbox("Different Name"),
+			},
+			{
+				Config: providerConfig(server.URL) + mailbox("Different Name"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "sender_denylist.#", "0"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "sender_allowlist.#", "0"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "delegations.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestMailboxListEntryResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{
+		Mailboxes: []model.Mailbox{
+			{
+				LocalPart:  "test",
+				DomainName: "example.com",
+				Address:    "test@example.com",
+			},
+		},
+	}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_mailbox_list_entry" "test" {
+						local_part  = "test"
+						domain_name = "example.com"
+						list        = "sender_denylist"
+						address     = "spam@example.org"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("migadu_mailbox_list_entry.test", map[string]knownvalue.Check{
+						"local_part":  knownvalue.StringExact("test"),
+						"domain_name": knownvalue.StringExact("example.com"),
+						"list":        knownvalue.StringExact("sender_denylist"),
+						"address":     knownvalue.StringExact("spam@example.org"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "migadu_mailbox_list_entry.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestMailboxListEntryResource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetMailbox: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetMailbox: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							resource "migadu_mailbox_list_entry" "test" {
+								local_part  = "user"
+								domain_name = "example.com"
+								list        = "sender_denylist"
+								address     = "someone@example.com"
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestMailboxListEntryResource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"empty-domain-name": {
+			Configuration: `
+				domain_name = ""
+				local_part  = "user"
+				list        = "sender_denylist"
+				address     = "someone@example.com"
+			`,
+			ErrorRegex: "Attribute domain_name string length must be at least 1",
+		},
+		"empty-local-part": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = ""
+				list        = "sender_denylist"
+				address     = "someone@example.com"
+			`,
+			ErrorRegex: "Attribute local_part string length must be at least 1",
+		},
+		"invalid-list": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "user"
+				list        = "delegations"
+				address     = "someone@example.com"
+			`,
+			ErrorRegex: `Attribute list value must be one of`,
+		},
+		"invalid-address": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "user"
+				list        = "sender_denylist"
+				address     = "someone"
+			`,
+			ErrorRegex: "An email must match the format 'local_part@domain'",
+		},
+		"missing-list": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "user"
+				address     = "someone@example.com"
+			`,
+			ErrorRegex: `The argument "list" is required, but no definition was found`,
+		},
+		"missing-address": {
+			Configuration: `
+				domain_name = "example.com"
+				local_part  = "user"
+				list        = "sender_denylist"
+			`,
+			ErrorRegex: `The argument "address" is required, but no definition was found`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							resource "migadu_mailbox_list_entry" "test" {
+								%s
+							}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+var (
+	_ list.ListResource              = (*MailboxListResource)(nil)
+	_ list.ListResourceWithConfigure = (*MailboxListResource)(nil)
+)
+
+func NewMailboxListResource() list.ListResource {
+	return &MailboxListResource{}
+}
+
+type MailboxListResource struct {
+	MigaduClient *client.MigaduClient
+}
+
+type MailboxListResourceModel struct {
+	DomainName custom_types.DomainNameValue `tfsdk:"domain_name"`
+}
+
+func (r *MailboxListResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_mailbox"
+}
+
+func (r *MailboxListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, response *list.ListResourceSchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Lists all mailboxes of a domain.",
+		MarkdownDescription: "Lists all mailboxes of a domain.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain name of the mailboxes.",
+				MarkdownDescription: "The domain name of the mailboxes.",
+				Required:            true,
+				Optional:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *MailboxListResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
+		r.MigaduClient = migaduClient
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (r *MailboxListResource) List(ctx context.Context, request list.ListRequest, stream *list.ListResultsStream) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var config MailboxListResourceModel
+	diags := request.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	mailboxes, err := r.MigaduClient.GetMailboxes(ctx, config.DomainName.ValueString())
+	if err != nil {
+		stream.Results = list.ListResultsStreamDiagnostics(MailboxReadError(ctx, err))
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, mailbox := range mailboxes.Mailboxes {
+			result := request.NewListResult(ctx)
+			result.DisplayName = mailbox.Address
+
+			state := MailboxResourceModel{
+				LocalPart:         types.StringValue(mailbox.LocalPart),
+				DomainName:        config.DomainName,
+				SenderDenyList:    custom_types.NewEmailAddressSetNull(),
+				SenderAllowList:   custom_types.NewEmailAddressSetNull(),
+				RecipientDenyList: custom_types.NewEmailAddressSetNull(),
+				Delegations:       custom_types.NewEmailAddressSetNull(),
+				Timeouts:          nullResourceTimeouts(),
+			}
+			result.Diagnostics.Append(refreshMailboxResourceModel(ctx, &state, &mailbox)...)
+			result.Diagnostics.Append(result.Identity.Set(ctx, newMailboxResourceIdentityModel(&state))...)
+			if request.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, &state)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,235 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwlist "github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestMailboxListResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwlist.ListResourceSchemaRequest{}
+	schemaResponse := &fwlist.ListResourceSchemaResponse{}
+
+	provider.NewMailboxListResource().ListResourceConfigSchema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestMailboxListResource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		config string
+		state  []model.Mailbox
+		want   int
+	}{
+		"empty": {
+			config: `
+				domain_name = "example.com"
+			`,
+			want: 0,
+		},
+		"single": {
+			config: `
+				domain_name = "example.com"
+			`,
+			state: []model.Mailbox{
+				{
+					LocalPart:  "test",
+					DomainName: "example.com",
+					Address:    "test@example.com",
+				},
+			},
+			want: 1,
+		},
+		"multiple": {
+			config: `
+				domain_name = "example.com"
+			`,
+			state: []model.Mailbox{
+				{
+					LocalPart:  "test",
+					DomainName: "example.com",
+					Address:    "test@example.com",
+				},
+				{
+					LocalPart:  "other",
+					DomainName: "example.com",
+					Address:    "other@example.com",
+				},
+			},
+			want: 2,
+		},
+		"other-domain": {
+			config: `
+				domain_name = "example.com"
+			`,
+			state: []model.Mailbox{
+				{
+					LocalPart:  "test",
+					DomainName: "example.com",
+					Address:    "test@example.com",
+				},
+				{
+					LocalPart:  "test",
+					DomainName: "example.org",
+					Address:    "test@example.org",
+				},
+			},
+			want: 1,
+		},
+		"idna": {
+			config: `
+				domain_name = "hoß.de"
+			`,
+			state: []model.Mailbox{
+				{
+					LocalPart:  "test",
+					DomainName: "xn--ho-hia.de",
+					Address:    "test@xn--ho-hia.de",
+				},
+			},
+			want: 1,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Mailboxes: testCase.state}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_14_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL),
+					},
+					{
+						Query: true,
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							list "migadu_mailbox" "test" {
+								provider = migadu
+
+								config {
+									%s
+								}
+							}
+						`, testCase.config),
+						QueryResultChecks: []querycheck.QueryResultCheck{
+							querycheck.ExpectLength("migadu_mailbox.test", testCase.want),
+						},
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestMailboxListResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Mailboxes: []model.Mailbox{
+		{
+			LocalPart:  "test",
+			DomainName: "example.com",
+			Address:    "test@example.com",
+		},
+	}}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL),
+			},
+			{
+				Query: true,
+				Config: providerConfig(server.URL) + `
+					list "migadu_mailbox" "test" {
+						provider = migadu
+
+						config {
+							domain_name = "example.com"
+						}
+					}
+				`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectIdentity("migadu_mailbox.test", map[string]knownvalue.Check{
+						"local_part":  knownvalue.StringExact("test"),
+						"domain_name": knownvalue.StringExact("example.com"),
+					}),
+				},
+			},
+		},
+	})
+}
+
+func TestMailboxListResource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetMailboxes: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetMailboxes: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_14_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL),
+					},
+					{
+						Query: true,
+						Config: providerConfig(server.URL) + `
+							list "migadu_mailbox" "test" {
+								provider = migadu
+
+								config {
+									domain_name = "example.com"
+								}
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
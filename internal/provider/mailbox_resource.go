@@ -6,13 +6,20 @@
 package provider
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
@@ -27,9 +34,12 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*MailboxResource)(nil)
-	_ resource.ResourceWithConfigure   = (*MailboxResource)(nil)
-	_ resource.ResourceWithImportState = (*MailboxResource)(nil)
+	_ resource.Resource                   = (*MailboxResource)(nil)
+	_ resource.ResourceWithConfigure      = (*MailboxResource)(nil)
+	_ resource.ResourceWithImportState    = (*MailboxResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*MailboxResource)(nil)
+	_ resource.ResourceWithIdentity       = (*MailboxResource)(nil)
+	_ resource.ResourceWithUpgradeState   = (*MailboxResource)(nil)
 )
 
 func NewMailboxResource() resource.Resource {
@@ -37,7 +47,9 @@ func NewMailboxResource() resource.Resource {
 }
 
 type MailboxResource struct {
-	MigaduClient *client.MigaduClient
+	MigaduClient  *client.MigaduClient
+	ReadCache     *ReadCache
+	AdoptExisting bool
 }
 
 type MailboxResourceModel struct {
@@ -53,6 +65,8 @@ type MailboxResourceModel struct {
 	MayAccessPop3         types.Bool                        `tfsdk:"may_access_pop3"`
 	MayAccessManageSieve  types.Bool                        `tfsdk:"may_access_manage_sieve"`
 	Password              types.String                      `tfsdk:"password"`
+	PasswordWO            types.String                      `tfsdk:"password_wo"`
+	PasswordWOVersion     types.Int64                       `tfsdk:"password_wo_version"`
 	PasswordRecoveryEmail custom_types.EmailAddressValue    `tfsdk:"password_recovery_email"`
 	PasswordMethod        types.String                      `tfsdk:"password_method"`
 	SpamAction            types.String                      `tfsdk:"spam_action"`
@@ -64,6 +78,7 @@ type MailboxResourceModel struct {
 	SenderAllowList       custom_types.EmailAddressSetValue `tfsdk:"sender_allowlist"`
 	RecipientDenyList     custom_types.EmailAddressSetValue `tfsdk:"recipient_denylist"`
 	Delegations           custom_types.EmailAddressSetValue `tfsdk:"delegations"`
+	ManageAutoresponder   types.Bool                        `tfsdk:"manage_autoresponder"`
 	AutoRespondActive     types.Bool                        `tfsdk:"auto_respond_active"`
 	AutoRespondSubject    types.String                      `tfsdk:"auto_respond_subject"`
 	AutoRespondBody       types.String                      `tfsdk:"auto_respond_body"`
@@ -71,16 +86,24 @@ type MailboxResourceModel struct {
 	FooterActive          types.Bool                        `tfsdk:"footer_active"`
 	FooterPlainBody       types.String                      `tfsdk:"footer_plain_body"`
 	FooterHtmlBody        types.String                      `tfsdk:"footer_html_body"`
+	AdoptExisting         types.Bool                        `tfsdk:"adopt_existing"`
+	Timeouts              timeouts.Value                    `tfsdk:"timeouts"`
+}
+
+type MailboxResourceIdentityModel struct {
+	LocalPart  types.String `tfsdk:"local_part"`
+	DomainName types.String `tfsdk:"domain_name"`
 }
 
 func (r *MailboxResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_mailbox"
 }
 
-func (r *MailboxResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+func (r *MailboxResource) Schema(ctx context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		Description:         "Provides a mailbox.",
 		MarkdownDescription: "Provides a mailbox.",
+		Version:             1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description:         "Contains the value 'local_part@domain_name'.",
@@ -191,10 +214,33 @@ func (r *MailboxResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:            true,
 				Sensitive:           true,
 				Validators: []validator.String{
-					stringvalidator.AtLeastOneOf(path.MatchRoot("password_recovery_email")),
+					stringvalidator.AtLeastOneOf(path.MatchRoot("password_recovery_email"), path.MatchRoot("password_wo")),
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"password_wo": schema.StringAttribute{
+				Description:         "The password of this mailbox. This value is never stored in the state. Change 'password_wo_version' in order to update the password. Requires Terraform 1.11 or later.",
+				MarkdownDescription: "The password of this mailbox. This value is never stored in the state. Change `password_wo_version` in order to update the password. Requires Terraform 1.11 or later.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+				Sensitive:           true,
+				WriteOnly:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("password")),
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
+			"password_wo_version": schema.Int64Attribute{
+				Description:         "The version of the 'password_wo' attribute. Change this value in order to send a new password to Migadu.",
+				MarkdownDescription: "The version of the `password_wo` attribute. Change this value in order to send a new password to Migadu.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+				Validators: []validator.Int64{
+					int64validator.AlsoRequires(path.MatchRoot("password_wo")),
+				},
+			},
 			"password_recovery_email": schema.StringAttribute{
 				Description:         "The recovery email address of this mailbox.",
 				MarkdownDescription: "The recovery email address of this mailbox.",
@@ -203,7 +249,7 @@ func (r *MailboxResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:            true,
 				CustomType:          custom_types.EmailAddressType{},
 				Validators: []validator.String{
-					stringvalidator.AtLeastOneOf(path.MatchRoot("password")),
+					stringvalidator.AtLeastOneOf(path.MatchRoot("password"), path.MatchRoot("password_wo")),
 					stringvalidator.LengthAtLeast(1),
 				},
 			},
@@ -254,8 +300,8 @@ func (r *MailboxResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:            true,
 			},
 			"sender_denylist": schema.SetAttribute{
-				Description:         "The email addresses of senders that will always be denied delivery.",
-				MarkdownDescription: "The email addresses of senders that will always be denied delivery.",
+				Description:         "The email addresses of senders that will always be denied delivery. Leave unset in case the entries are managed by 'migadu_mailbox_list_entry' resources.",
+				MarkdownDescription: "The email addresses of senders that will always be denied delivery. Leave unset in case the entries are managed by `migadu_mailbox_list_entry` resources.",
 				Required:            false,
 				Optional:            true,
 				Computed:            true,
@@ -266,8 +312,8 @@ func (r *MailboxResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"sender_allowlist": schema.SetAttribute{
-				Description:         "The email addresses of senders that will always be allowed delivery.",
-				MarkdownDescription: "The email addresses of senders that will always be allowed delivery.",
+				Description:         "The email addresses of senders that will always be allowed delivery. Leave unset in case the entries are managed by 'migadu_mailbox_list_entry' resources.",
+				MarkdownDescription: "The email addresses of senders that will always be allowed delivery. Leave unset in case the entries are managed by `migadu_mailbox_list_entry` resources.",
 				Required:            false,
 				Optional:            true,
 				Computed:            true,
@@ -278,8 +324,8 @@ func (r *MailboxResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"recipient_denylist": schema.SetAttribute{
-				Description:         "The email addresses of recipients that will always be denied delivery.",
-				MarkdownDescription: "The email addresses of recipients that will always be denied delivery.",
+				Description:         "The email addresses of recipients that will always be denied delivery. Leave unset in case the entries are managed by 'migadu_mailbox_list_entry' resources.",
+				MarkdownDescription: "The email addresses of recipients that will always be denied delivery. Leave unset in case the entries are managed by `migadu_mailbox_list_entry` resources.",
 				Required:            false,
 				Optional:            true,
 				Computed:            true,
@@ -290,8 +336,8 @@ func (r *MailboxResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				},
 			},
 			"delegations": schema.SetAttribute{
-				Description:         "The delegations of the mailbox.",
-				MarkdownDescription: "The delegations of the mailbox.",
+				Description:         "The delegations of the mailbox. Leave unset in case the entries are managed by 'migadu_mailbox_delegation' resources.",
+				MarkdownDescription: "The delegations of the mailbox. Leave unset in case the entries are managed by `migadu_mailbox_delegation` resources.",
 				Required:            false,
 				Optional:            true,
 				Computed:            true,
@@ -301,6 +347,14 @@ func (r *MailboxResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					},
 				},
 			},
+			"manage_autoresponder": schema.BoolAttribute{
+				Description:         "Whether the automatic response of this mailbox is managed by this resource. Set to 'false' in case the automatic response is managed by a 'migadu_mailbox_autoresponder' resource in order to leave the 'auto_respond_*' attributes untouched. Defaults to 'true'.",
+				MarkdownDescription: "Whether the automatic response of this mailbox is managed by this resource. Set to `false` in case the automatic response is managed by a `migadu_mailbox_autoresponder` resource in order to leave the `auto_respond_*` attributes untouched. Defaults to `true`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
 			"auto_respond_active": schema.BoolAttribute{
 				Description:         "Whether an automatic response is active in this mailbox.",
 				MarkdownDescription: "Whether an automatic response is active in this mailbox.",
@@ -350,35 +404,122 @@ func (r *MailboxResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:            true,
 				Computed:            true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description:         "Whether to adopt an existing mailbox with the same identifier instead of failing to create it, e.g. after a previous apply timed out after the mailbox was created. The adopted mailbox is updated to match this resource. Defaults to the 'adopt_existing' attribute of the provider.",
+				MarkdownDescription: "Whether to adopt an existing mailbox with the same identifier instead of failing to create it, e.g. after a previous apply timed out after the mailbox was created. The adopted mailbox is updated to match this resource. Defaults to the `adopt_existing` attribute of the provider.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *MailboxResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"local_part": identityschema.StringAttribute{
+				Description:       "The local part of the mailbox.",
+				RequiredForImport: true,
+			},
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the mailbox.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *MailboxResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// version 0 includes the list based state with '*_punycode' attributes of releases before 2023.8.23
+		0: {
+			StateUpgrader: upgradeStateFromRawState,
 		},
 	}
 }
+
 func (r *MailboxResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
 	if request.ProviderData == nil {
 		return
 	}
 
-	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
-		r.MigaduClient = migaduClient
+	if providerData, ok := request.ProviderData.(*MigaduProviderData); ok {
+		r.MigaduClient = providerData.Client
+		r.ReadCache = providerData.ReadCache
+		r.AdoptExisting = providerData.AdoptExisting
 	} else {
 		response.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *provider.MigaduProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 	}
 }
 
+func (r *MailboxResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var config MailboxResourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ManageAutoresponder.IsNull() || config.ManageAutoresponder.IsUnknown() || config.ManageAutoresponder.ValueBool() {
+		return
+	}
+
+	autoRespondAttributes := []struct {
+		name  string
+		value attr.Value
+	}{
+		{name: "auto_respond_active", value: config.AutoRespondActive},
+		{name: "auto_respond_subject", value: config.AutoRespondSubject},
+		{name: "auto_respond_body", value: config.AutoRespondBody},
+		{name: "auto_respond_expires_on", value: config.AutoRespondExpiresOn},
+	}
+	for _, attribute := range autoRespondAttributes {
+		if !attribute.value.IsNull() {
+			response.Diagnostics.AddAttributeError(
+				path.Root(attribute.name),
+				"Invalid Attribute Combination",
+				fmt.Sprintf("Cannot use '%s' with 'manage_autoresponder = false'", attribute.name),
+			)
+		}
+	}
+}
+
 func (r *MailboxResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var plan MailboxResourceModel
 	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	if plan.PasswordMethod.ValueString() == "password" && plan.Password.ValueString() == "" {
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var passwordWO types.String
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.PasswordMethod.ValueString() == "password" && plan.Password.ValueString() == "" && passwordWO.ValueString() == "" {
 		response.Diagnostics.AddError(
 			"Error creating mailbox",
-			"Cannot use 'password_method = password' without a 'password'",
+			"Cannot use 'password_method = password' without a 'password' or 'password_wo'",
 		)
 		return
 	}
@@ -439,7 +580,7 @@ func (r *MailboxResource) Create(ctx context.Context, request resource.CreateReq
 		MayAccessImap:         plan.MayAccessImap.ValueBool(),
 		MayAccessPop3:         plan.MayAccessPop3.ValueBool(),
 		MayAccessManageSieve:  plan.MayAccessManageSieve.ValueBool(),
-		Password:              plan.Password.ValueString(),
+		Password:              cmp.Or(plan.Password.ValueString(), passwordWO.ValueString()),
 		PasswordRecoveryEmail: plan.PasswordRecoveryEmail.ValueString(),
 		PasswordMethod:        plan.PasswordMethod.ValueString(),
 		SpamAction:            plan.SpamAction.ValueString(),
@@ -461,8 +602,14 @@ func (r *MailboxResource) Create(ctx context.Context, request resource.CreateReq
 	}
 
 	createdMailbox, err := r.MigaduClient.CreateMailbox(ctx, plan.DomainName.ValueString(), mailbox)
-	if err != nil {
-		response.Diagnostics.Append(MailboxCreateError(err))
+	if err != nil && isConflictError(err) && adoptExisting(plan.AdoptExisting, r.AdoptExisting) {
+		createdMailbox, diags = r.adopt(ctx, &plan, mailbox, err)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	} else if err != nil {
+		response.Diagnostics.Append(MailboxCreateError(ctx, err)...)
 		return
 	}
 
@@ -491,16 +638,93 @@ func (r *MailboxResource) Create(ctx context.Context, request resource.CreateReq
 	plan.FooterHtmlBody = types.StringValue(createdMailbox.FooterHtmlBody)
 
 	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newMailboxResourceIdentityModel(&plan))...)
+}
+
+// adopt updates the existing mailbox of the given plan to match the given mailbox. Unconfigured lists and unmanaged
+// automatic responses keep their remote values just like in Update. The given error of the failed create request is
+// reported in case no mailbox with the same address exists, e.g. because an alias uses the address.
+func (r *MailboxResource) adopt(ctx context.Context, plan *MailboxResourceModel, mailbox *model.Mailbox, createErr error) (*model.Mailbox, diag.Diagnostics) {
+	var diagnostics diag.Diagnostics
+	createDiagnostics := MailboxCreateError(ctx, createErr)
+
+	unlock := lockObject("mailbox", plan.LocalPart.ValueString(), plan.DomainName.ValueString())
+	defer unlock()
+
+	currentMailbox, err := r.MigaduClient.GetMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString())
+	if isNotFoundError(err) {
+		return nil, createDiagnostics
+	}
+	if err != nil {
+		return nil, MailboxReadError(ctx, err)
+	}
+
+	tflog.Info(ctx, "Adopting existing mailbox", map[string]interface{}{
+		"local_part":  plan.LocalPart.ValueString(),
+		"domain_name": plan.DomainName.ValueString(),
+	})
+
+	var diags diag.Diagnostics
+	if plan.SenderDenyList.IsNull() {
+		mailbox.SenderDenyList = currentMailbox.SenderDenyList
+		plan.SenderDenyList, diags = custom_types.NewEmailAddressSetValueFrom(ctx, mailbox.SenderDenyList)
+		diagnostics.Append(diags...)
+	}
+	if plan.SenderAllowList.IsNull() {
+		mailbox.SenderAllowList = currentMailbox.SenderAllowList
+		plan.SenderAllowList, diags = custom_types.NewEmailAddressSetValueFrom(ctx, mailbox.SenderAllowList)
+		diagnostics.Append(diags...)
+	}
+	if plan.RecipientDenyList.IsNull() {
+		mailbox.RecipientDenyList = currentMailbox.RecipientDenyList
+		plan.RecipientDenyList, diags = custom_types.NewEmailAddressSetValueFrom(ctx, mailbox.RecipientDenyList)
+		diagnostics.Append(diags...)
+	}
+	if plan.Delegations.IsNull() {
+		mailbox.Delegations = currentMailbox.Delegations
+		plan.Delegations, diags = custom_types.NewEmailAddressSetValueFrom(ctx, mailbox.Delegations)
+		diagnostics.Append(diags...)
+	}
+	if diagnostics.HasError() {
+		return nil, diagnostics
+	}
+
+	if !plan.ManageAutoresponder.ValueBool() {
+		mailbox.AutoRespondActive = currentMailbox.AutoRespondActive
+		mailbox.AutoRespondSubject = currentMailbox.AutoRespondSubject
+		mailbox.AutoRespondBody = currentMailbox.AutoRespondBody
+		mailbox.AutoRespondExpiresOn = currentMailbox.AutoRespondExpiresOn
+	}
+
+	// the password method only applies to new mailboxes and is never sent by Update either
+	mailbox.PasswordMethod = ""
+
+	updatedMailbox, err := r.MigaduClient.UpdateMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), mailbox)
+	if err != nil {
+		return nil, MailboxUpdateError(ctx, err)
+	}
+	return updatedMailbox, diagnostics
 }
 
 func (r *MailboxResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var state MailboxResourceModel
 	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	mailbox, err := r.MigaduClient.GetMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	mailbox, err := r.ReadCache.GetMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
 	if err != nil {
 		var requestError *client.RequestError
 		if errors.As(err, &requestError) {
@@ -509,61 +733,75 @@ func (r *MailboxResource) Read(ctx context.Context, request resource.ReadRequest
 				return
 			}
 		}
-		response.Diagnostics.Append(MailboxReadError(err))
+		response.Diagnostics.Append(MailboxReadError(ctx, err)...)
 		return
 	}
 
-	senderDenyList, diags := custom_types.NewEmailAddressSetValueFrom(ctx, mailbox.SenderDenyList)
-	response.Diagnostics.Append(diags...)
+	response.Diagnostics.Append(refreshMailboxResourceModel(ctx, &state, mailbox)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newMailboxResourceIdentityModel(&state))...)
+}
+
+// refreshMailboxResourceModel updates the given state with the values of the given mailbox. Values that are semantically
+// equal to the current state are kept.
+func refreshMailboxResourceModel(ctx context.Context, state *MailboxResourceModel, mailbox *model.Mailbox) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	senderDenyList, diags := custom_types.NewEmailAddressSetValueFrom(ctx, mailbox.SenderDenyList)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
 	senderDenyListEqual, diags := state.SenderDenyList.SetSemanticEquals(ctx, senderDenyList)
-	response.Diagnostics.Append(diags...)
-	if response.Diagnostics.HasError() {
-		return
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
 	}
 	if !senderDenyListEqual {
 		state.SenderDenyList = senderDenyList
 	}
 
 	senderAllowList, diags := custom_types.NewEmailAddressSetValueFrom(ctx, mailbox.SenderAllowList)
-	response.Diagnostics.Append(diags...)
-	if response.Diagnostics.HasError() {
-		return
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
 	}
 	senderAllowListEqual, diags := state.SenderAllowList.SetSemanticEquals(ctx, senderAllowList)
-	response.Diagnostics.Append(diags...)
-	if response.Diagnostics.HasError() {
-		return
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
 	}
 	if !senderAllowListEqual {
 		state.SenderAllowList = senderAllowList
 	}
 
 	recipientDenyList, diags := custom_types.NewEmailAddressSetValueFrom(ctx, mailbox.RecipientDenyList)
-	response.Diagnostics.Append(diags...)
-	if response.Diagnostics.HasError() {
-		return
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
 	}
 	recipientDenyListEqual, diags := state.RecipientDenyList.SetSemanticEquals(ctx, recipientDenyList)
-	response.Diagnostics.Append(diags...)
-	if response.Diagnostics.HasError() {
-		return
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
 	}
 	if !recipientDenyListEqual {
 		state.RecipientDenyList = recipientDenyList
 	}
 
 	delegations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, mailbox.Delegations)
-	response.Diagnostics.Append(diags...)
-	if response.Diagnostics.HasError() {
-		return
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
 	}
 	delegationsEqual, diags := state.Delegations.SetSemanticEquals(ctx, delegations)
-	response.Diagnostics.Append(diags...)
-	if response.Diagnostics.HasError() {
-		return
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
 	}
 	if !delegationsEqual {
 		state.Delegations = delegations
@@ -599,54 +837,61 @@ func (r *MailboxResource) Read(ctx context.Context, request resource.ReadRequest
 	state.FooterPlainBody = types.StringValue(mailbox.FooterPlainBody)
 	state.FooterHtmlBody = types.StringValue(mailbox.FooterHtmlBody)
 
-	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	return diagnostics
+}
+
+func newMailboxResourceIdentityModel(state *MailboxResourceModel) MailboxResourceIdentityModel {
+	return MailboxResourceIdentityModel{
+		LocalPart:  types.StringValue(state.LocalPart.ValueString()),
+		DomainName: types.StringValue(state.DomainName.ValueString()),
+	}
 }
 
 func (r *MailboxResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var plan MailboxResourceModel
 	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	var state MailboxResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// unconfigured lists are unknown and keep their remote entries, e.g. those managed by migadu_mailbox_delegation or migadu_mailbox_list_entry resources
 	var senderDenyList []string
-	if plan.SenderDenyList.IsUnknown() {
-		plan.SenderDenyList = custom_types.NewEmailAddressSetNull()
-	} else {
+	if !plan.SenderDenyList.IsUnknown() {
 		response.Diagnostics.Append(plan.SenderDenyList.ElementsAs(ctx, &senderDenyList, false)...)
-		if response.Diagnostics.HasError() {
-			return
-		}
 	}
-
 	var senderAllowList []string
-	if plan.SenderAllowList.IsUnknown() {
-		plan.SenderAllowList = custom_types.NewEmailAddressSetNull()
-	} else {
+	if !plan.SenderAllowList.IsUnknown() {
 		response.Diagnostics.Append(plan.SenderAllowList.ElementsAs(ctx, &senderAllowList, false)...)
-		if response.Diagnostics.HasError() {
-			return
-		}
 	}
-
 	var recipientDenyList []string
-	if plan.RecipientDenyList.IsUnknown() {
-		plan.RecipientDenyList = custom_types.NewEmailAddressSetNull()
-	} else {
+	if !plan.RecipientDenyList.IsUnknown() {
 		response.Diagnostics.Append(plan.RecipientDenyList.ElementsAs(ctx, &recipientDenyList, false)...)
-		if response.Diagnostics.HasError() {
-			return
-		}
 	}
-
 	var delegations []string
-	if plan.Delegations.IsUnknown() {
-		plan.Delegations = custom_types.NewEmailAddressSetNull()
-	} else {
+	if !plan.Delegations.IsUnknown() {
 		response.Diagnostics.Append(plan.Delegations.ElementsAs(ctx, &delegations, false)...)
-		if response.Diagnostics.HasError() {
-			return
-		}
+	}
+	if response.Diagnostics.HasError() {
+		return
 	}
 
 	mailbox := &model.Mailbox{
@@ -677,9 +922,85 @@ func (r *MailboxResource) Update(ctx context.Context, request resource.UpdateReq
 		FooterHtmlBody:        plan.FooterHtmlBody.ValueString(),
 	}
 
-	updatedMailbox, err := r.MigaduClient.UpdateMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString(), mailbox)
+	// only changed fields are sent in order to keep changes made outside of Terraform, e.g. in the webmail
+	fields := newRequestFields(mailbox)
+	fields.add(&mailbox.Name, plan.Name, state.Name)
+	fields.add(&mailbox.IsInternal, plan.IsInternal, state.IsInternal)
+	fields.add(&mailbox.MaySend, plan.MaySend, state.MaySend)
+	fields.add(&mailbox.MayReceive, plan.MayReceive, state.MayReceive)
+	fields.add(&mailbox.MayAccessImap, plan.MayAccessImap, state.MayAccessImap)
+	fields.add(&mailbox.MayAccessPop3, plan.MayAccessPop3, state.MayAccessPop3)
+	fields.add(&mailbox.MayAccessManageSieve, plan.MayAccessManageSieve, state.MayAccessManageSieve)
+	if mailbox.Password != "" {
+		fields.add(&mailbox.Password, plan.Password, state.Password)
+	}
+	fields.add(&mailbox.PasswordRecoveryEmail, plan.PasswordRecoveryEmail, state.PasswordRecoveryEmail)
+	fields.add(&mailbox.SpamAction, plan.SpamAction, state.SpamAction)
+	fields.add(&mailbox.SpamAggressiveness, plan.SpamAggressiveness, state.SpamAggressiveness)
+	fields.add(&mailbox.Expirable, plan.Expirable, state.Expirable)
+	fields.add(&mailbox.ExpiresOn, plan.ExpiresOn, state.ExpiresOn)
+	fields.add(&mailbox.RemoveUponExpiry, plan.RemoveUponExpiry, state.RemoveUponExpiry)
+	fields.add(&mailbox.SenderDenyList, plan.SenderDenyList, state.SenderDenyList)
+	fields.add(&mailbox.SenderAllowList, plan.SenderAllowList, state.SenderAllowList)
+	fields.add(&mailbox.RecipientDenyList, plan.RecipientDenyList, state.RecipientDenyList)
+	fields.add(&mailbox.Delegations, plan.Delegations, state.Delegations)
+	if plan.ManageAutoresponder.ValueBool() {
+		fields.add(&mailbox.AutoRespondActive, plan.AutoRespondActive, state.AutoRespondActive)
+		fields.add(&mailbox.AutoRespondSubject, plan.AutoRespondSubject, state.AutoRespondSubject)
+		fields.add(&mailbox.AutoRespondBody, plan.AutoRespondBody, state.AutoRespondBody)
+		fields.add(&mailbox.AutoRespondExpiresOn, plan.AutoRespondExpiresOn, state.AutoRespondExpiresOn)
+	}
+	fields.add(&mailbox.FooterActive, plan.FooterActive, state.FooterActive)
+	fields.add(&mailbox.FooterPlainBody, plan.FooterPlainBody, state.FooterPlainBody)
+	fields.add(&mailbox.FooterHtmlBody, plan.FooterHtmlBody, state.FooterHtmlBody)
+
+	// write-only passwords are only sent once their version changes
+	if !plan.PasswordWOVersion.Equal(state.PasswordWOVersion) {
+		var passwordWO types.String
+		response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("password_wo"), &passwordWO)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+		if password := cmp.Or(plan.Password.ValueString(), passwordWO.ValueString()); password != "" {
+			mailbox.Password = password
+			fields.addAlways(&mailbox.Password)
+		}
+	}
+
+	// non-authoritative resources like migadu_mailbox_list_entry send the entire mailbox after reading it
+	unlock := lockObject("mailbox", plan.LocalPart.ValueString(), plan.DomainName.ValueString())
+	defer unlock()
+
+	var updatedMailbox *model.Mailbox
+	var err error
+	if fields.empty() {
+		// nothing to send in case only attributes of this provider changed, e.g. the timeouts
+		updatedMailbox, err = r.MigaduClient.GetMailbox(ctx, plan.DomainName.ValueString(), plan.LocalPart.ValueString())
+	} else {
+		updatedMailbox, err = r.MigaduClient.UpdateMailbox(fields.context(ctx), plan.DomainName.ValueString(), plan.LocalPart.ValueString(), mailbox)
+	}
 	if err != nil {
-		response.Diagnostics.Append(MailboxUpdateError(err))
+		response.Diagnostics.Append(MailboxUpdateError(ctx, err)...)
+		return
+	}
+
+	if plan.SenderDenyList.IsUnknown() {
+		plan.SenderDenyList, diags = custom_types.NewEmailAddressSetValueFrom(ctx, updatedMailbox.SenderDenyList)
+		response.Diagnostics.Append(diags...)
+	}
+	if plan.SenderAllowList.IsUnknown() {
+		plan.SenderAllowList, diags = custom_types.NewEmailAddressSetValueFrom(ctx, updatedMailbox.SenderAllowList)
+		response.Diagnostics.Append(diags...)
+	}
+	if plan.RecipientDenyList.IsUnknown() {
+		plan.RecipientDenyList, diags = custom_types.NewEmailAddressSetValueFrom(ctx, updatedMailbox.RecipientDenyList)
+		response.Diagnostics.Append(diags...)
+	}
+	if plan.Delegations.IsUnknown() {
+		plan.Delegations, diags = custom_types.NewEmailAddressSetValueFrom(ctx, updatedMailbox.Delegations)
+		response.Diagnostics.Append(diags...)
+	}
+	if response.Diagnostics.HasError() {
 		return
 	}
 
@@ -711,20 +1032,59 @@ func (r *MailboxResource) Update(ctx context.Context, request resource.UpdateReq
 }
 
 func (r *MailboxResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var state MailboxResourceModel
 	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.ReadCache.Invalidate(state.DomainName.ValueString())
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	_, err := r.MigaduClient.DeleteMailbox(ctx, state.DomainName.ValueString(), state.LocalPart.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(MailboxDeleteError(err))
+		response.Diagnostics.Append(MailboxDeleteError(ctx, err)...)
 		return
 	}
 }
 
 func (r *MailboxResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID == "" && request.Identity != nil && !request.Identity.Raw.IsNull() {
+		var resourceIdentity MailboxResourceIdentityModel
+		response.Diagnostics.Append(request.Identity.Get(ctx, &resourceIdentity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		localPart := resourceIdentity.LocalPart.ValueString()
+		domainName := resourceIdentity.DomainName.ValueString()
+
+		if localPart == "" || domainName == "" {
+			response.Diagnostics.Append(MailboxImportIdentityError())
+			return
+		}
+
+		tflog.Trace(ctx, "read import identity", map[string]interface{}{
+			"local_part":  localPart,
+			"domain_name": domainName,
+		})
+
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), CreateMailboxIDString(localPart, domainName))...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("local_part"), localPart)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+		return
+	}
+
 	idParts := strings.Split(request.ID, "@")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
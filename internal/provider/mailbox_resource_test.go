@@ -6,15 +6,24 @@
 package provider_test
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/metio/migadu-client.go/model"
 	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"regexp"
 	"strconv"
 	"testing"
+	"time"
 )
 
 func TestMailboxResource_API_Success_Using_Password(t *testing.T) {
@@ -271,6 +280,323 @@ func TestMailboxResource_API_Success_Using_RecoveryEmail(t *testing.T) {
 	}
 }
 
+func TestMailboxResource_API_Success_Using_WriteOnly_Password(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	mailbox := func(version int) string {
+		return fmt.Sprintf(`
+			resource "migadu_mailbox" "test" {
+				local_part          = "test"
+				domain_name         = "example.com"
+				name                = "Some Name"
+				password_wo         = "secret-%d"
+				password_wo_version = %d
+			}
+		`, version, version)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + mailbox(1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "id", "test@example.com"),
+					resource.TestCheckNoResourceAttr("migadu_mailbox.test", "password_wo"),
+					resource.TestCheckNoResourceAttr("migadu_mailbox.test", "password"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "password_wo_version", "1"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + mailbox(2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("migadu_mailbox.test", "password_wo"),
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "password_wo_version", "2"),
+				),
+			},
+			{
+				Config:   providerConfig(server.URL) + mailbox(2),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestMailboxResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_mailbox" "test" {
+						local_part              = "test"
+						domain_name             = "example.com"
+						name                    = "Some Name"
+						password_recovery_email = "someone@example.com"
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("migadu_mailbox.test", map[string]knownvalue.Check{
+						"local_part":  knownvalue.StringExact("test"),
+						"domain_name": knownvalue.StringExact("example.com"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "migadu_mailbox.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestMailboxResource_UpgradeState(t *testing.T) {
+	state := upgradeState(t, provider.NewMailboxResource(), 0, `{
+		"id": "test@hoß.de",
+		"local_part": "test",
+		"domain_name": "hoß.de",
+		"address": "test@xn--ho-hia.de",
+		"name": "Some Name",
+		"is_internal": false,
+		"may_send": true,
+		"may_receive": true,
+		"may_access_imap": true,
+		"may_access_pop3": true,
+		"may_access_manage_sieve": true,
+		"password_recovery_email": "someone@example.com",
+		"password_method": "invitation",
+		"spam_action": "folder",
+		"spam_aggressiveness": "default",
+		"sender_denylist": ["spam@hoß.de"],
+		"sender_denylist_punycode": ["spam@xn--ho-hia.de"],
+		"sender_allowlist": [],
+		"sender_allowlist_punycode": [],
+		"recipient_denylist": ["other@example.com", "another@example.com"],
+		"recipient_denylist_punycode": ["other@example.com", "another@example.com"],
+		"delegations": ["delegate@example.com"],
+		"delegations_punycode": ["delegate@example.com"],
+		"identities": ["identity@example.com"],
+		"identities_punycode": ["identity@example.com"],
+		"auto_respond_active": false,
+		"auto_respond_subject": "",
+		"auto_respond_body": "",
+		"auto_respond_expires_on": "",
+		"footer_active": false,
+		"footer_plain_body": "",
+		"footer_html_body": ""
+	}`)
+
+	var upgraded provider.MailboxResourceModel
+	diagnostics := state.Get(context.Background(), &upgraded)
+	if diagnostics.HasError() {
+		t.Fatalf("State diagnostics: %+v", diagnostics)
+	}
+
+	var senderDenyList, recipientDenyList, delegations []string
+	upgraded.SenderDenyList.ElementsAs(context.Background(), &senderDenyList, false)
+	upgraded.RecipientDenyList.ElementsAs(context.Background(), &recipientDenyList, false)
+	upgraded.Delegations.ElementsAs(context.Background(), &delegations, false)
+
+	assert.Equal(t, "Some Name", upgraded.Name.ValueString(), "name")
+	assert.ElementsMatch(t, []string{"spam@hoß.de"}, senderDenyList, "sender_denylist")
+	assert.ElementsMatch(t, []string{"other@example.com", "another@example.com"}, recipientDenyList, "recipient_denylist")
+	assert.ElementsMatch(t, []string{"delegate@example.com"}, delegations, "delegations")
+	assert.True(t, upgraded.PasswordWOVersion.IsNull(), "password_wo_version")
+}
+
+func TestMailboxResource_API_Timeouts(t *testing.T) {
+	api := newLatencyInjector(simulator.MigaduAPI(t, &simulator.State{}))
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	config := func(value string) string {
+		return providerConfig(server.URL) + fmt.Sprintf(`
+			resource "migadu_mailbox" "test" {
+				local_part              = "test"
+				domain_name             = "example.com"
+				name                    = "%s"
+				password_recovery_email = "someone@example.com"
+
+				timeouts {
+					create = "1s"
+					read   = "1s"
+					update = "1s"
+					delete = "1s"
+				}
+			}
+		`, value)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { api.Inject(http.MethodPost, 2*time.Second) },
+				Config:      config("Some Name"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig: func() { api.Inject("", 0) },
+				Config:    config("Some Name"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodGet, 2*time.Second) },
+				Config:      config("Some Name"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodPut, 2*time.Second) },
+				Config:      config("Other Name"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodDelete, 2*time.Second) },
+				Config:      config("Other Name"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig: func() { api.Inject("", 0) },
+				Config:    config("Other Name"),
+			},
+		},
+	})
+}
+
+func TestMailboxResource_API_PartialUpdate(t *testing.T) {
+	api := newBodyRecorder(simulator.MigaduAPI(t, &simulator.State{}), http.MethodPut)
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_mailbox" "test" {
+						name        = "Old Name"
+						local_part  = "test"
+						domain_name = "example.com"
+						password    = "secret"
+					}
+				`,
+			},
+			{
+				PreConfig: func() { api.Fields() },
+				Config: providerConfig(server.URL) + `
+					resource "migadu_mailbox" "test" {
+						name        = "New Name"
+						local_part  = "test"
+						domain_name = "example.com"
+						password    = "secret"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_mailbox.test", "name", "New Name"),
+					func(_ *terraform.State) error {
+						if got, want := api.Fields(), [][]string{{"name"}}; !reflect.DeepEqual(got, want) {
+							return fmt.Errorf("expected update requests with fields %v, got %v", want, got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestMailboxResource_API_AdoptExisting(t *testing.T) {
+	testCases := map[string]struct {
+		provider string
+		resource string
+	}{
+		"resource": {
+			resource: "adopt_existing = true",
+		},
+		"provider": {
+			provider: "adopt_existing = true",
+		},
+		"resource-overrides-provider": {
+			provider: "adopt_existing = false",
+			resource: "adopt_existing = true",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(conflictOnCreate(simulator.MigaduAPI(t, &simulator.State{
+				Mailboxes: []model.Mailbox{
+					{
+						LocalPart:          "test",
+						DomainName:         "example.com",
+						Address:            "test@example.com",
+						Name:               "Old Name",
+						SenderDenyList:     []string{"spam@example.com"},
+						AutoRespondActive:  true,
+						AutoRespondSubject: "Out of office",
+					},
+				},
+			})))
+			defer server.Close()
+
+			config := func(adopt bool) string {
+				providerSettings, resourceSettings := "", ""
+				if adopt {
+					providerSettings, resourceSettings = testCase.provider, testCase.resource
+				}
+				return fmt.Sprintf(`
+					provider "migadu" {
+						username = "username"
+						token    = "token"
+						endpoint = "%s"
+						%s
+					}
+
+					resource "migadu_mailbox" "test" {
+						name                 = "Some Name"
+						local_part           = "test"
+						domain_name          = "example.com"
+						password             = "secret"
+						manage_autoresponder = false
+						%s
+					}
+				`, server.URL, providerSettings, resourceSettings)
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      config(false),
+						ExpectError: regexp.MustCompile("Error Creating Mailbox: Conflict"),
+					},
+					{
+						Config: config(true),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_mailbox.test", "address", "test@example.com"),
+							resource.TestCheckResourceAttr("migadu_mailbox.test", "name", "Some Name"),
+							resource.TestCheckResourceAttr("migadu_mailbox.test", "sender_denylist.#", "1"),
+							resource.TestCheckResourceAttr("migadu_mailbox.test", "sender_denylist.0", "spam@example.com"),
+							resource.TestCheckResourceAttr("migadu_mailbox.test", "auto_respond_active", "true"),
+							resource.TestCheckResourceAttr("migadu_mailbox.test", "auto_respond_subject", "Out of office"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
 func TestMailboxResource_API_Errors(t *testing.T) {
 	testCases := map[string]APIErrorTestCase{
 		"error-400": {
@@ -478,7 +804,7 @@ func TestMailboxResource_Configuration_Errors(t *testing.T) {
 				domain_name = "example.com"
 				local_part  = "test"
 			`,
-			ErrorRegex: `At least one attribute out of \[password,password_recovery_email\]`,
+			ErrorRegex: `At least one attribute out of \[password,password_recovery_email,password_wo\]`,
 		},
 		"empty-password-recovery-email": {
 			Configuration: `
@@ -495,7 +821,7 @@ func TestMailboxResource_Configuration_Errors(t *testing.T) {
 				domain_name = "example.com"
 				local_part  = "test"
 			`,
-			ErrorRegex: `At least one attribute out of \[password_recovery_email,password\]`,
+			ErrorRegex: `At least one attribute out of \[password_recovery_email,password,password_wo\]`,
 		},
 		"empty-name": {
 			Configuration: `
@@ -544,6 +870,46 @@ func TestMailboxResource_Configuration_Errors(t *testing.T) {
 			`,
 			ErrorRegex: "Attribute password_method value must be one of",
 		},
+		"password-and-write-only-password": {
+			Configuration: `
+				name        = "Some Name"
+				domain_name = "example.com"
+				local_part  = "test"
+				password    = "secret"
+				password_wo = "secret"
+			`,
+			ErrorRegex: `Attribute "password" cannot be specified when "password_wo" is\s+specified`,
+		},
+		"empty-write-only-password": {
+			Configuration: `
+				name        = "Some Name"
+				domain_name = "example.com"
+				local_part  = "test"
+				password_wo = ""
+			`,
+			ErrorRegex: "Attribute password_wo string length must be at least 1",
+		},
+		"write-only-password-version-without-password": {
+			Configuration: `
+				name                    = "Some Name"
+				domain_name             = "example.com"
+				local_part              = "test"
+				password_recovery_email = "someone@example.com"
+				password_wo_version     = 1
+			`,
+			ErrorRegex: `Attribute "password_wo" must be specified when "password_wo_version" is\s+specified`,
+		},
+		"unmanaged-autoresponder": {
+			Configuration: `
+				name                 = "Some Name"
+				domain_name          = "example.com"
+				local_part           = "test"
+				password             = "secret"
+				manage_autoresponder = false
+				auto_respond_active  = true
+			`,
+			ErrorRegex: "Cannot use 'auto_respond_active' with 'manage_autoresponder = false'",
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
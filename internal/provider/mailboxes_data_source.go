@@ -337,6 +337,8 @@ func (d *MailboxesDataSource) Configure(_ context.Context, request datasource.Co
 }
 
 func (d *MailboxesDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var data MailboxesDataSourceModel
 	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
 	if response.Diagnostics.HasError() {
@@ -345,7 +347,7 @@ func (d *MailboxesDataSource) Read(ctx context.Context, request datasource.ReadR
 
 	mailboxes, err := d.migaduClient.GetMailboxes(ctx, data.DomainName.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(MailboxReadError(err))
+		response.Diagnostics.Append(MailboxReadError(ctx, err)...)
 		return
 	}
 
@@ -0,0 +1,57 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = (*NormalizeEmailFunction)(nil)
+)
+
+func NewNormalizeEmailFunction() function.Function {
+	return &NormalizeEmailFunction{}
+}
+
+type NormalizeEmailFunction struct{}
+
+func (f *NormalizeEmailFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "normalize_email"
+}
+
+func (f *NormalizeEmailFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Normalizes an email address.",
+		Description:         "Normalizes an email address to its lowercase ASCII representation, e.g. 'Info@Bücher.example' becomes 'info@xn--bcher-kva.example'. Email addresses that normalize to the same value are considered equal by all resources of this provider.",
+		MarkdownDescription: "Normalizes an email address to its lowercase ASCII representation, e.g. `Info@Bücher.example` becomes `info@xn--bcher-kva.example`. Email addresses that normalize to the same value are considered equal by all resources of this provider.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "email",
+				Description:         "The email address to normalize.",
+				MarkdownDescription: "The email address to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeEmailFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var email string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &email))
+	if response.Error != nil {
+		return
+	}
+
+	normalized, funcErr := normalizeEmailArgument(0, email)
+	if funcErr != nil {
+		response.Error = funcErr
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, normalized))
+}
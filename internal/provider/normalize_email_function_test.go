@@ -0,0 +1,129 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwfunction "github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"regexp"
+	"testing"
+)
+
+func TestNormalizeEmailFunction_Definition(t *testing.T) {
+	ctx := context.Background()
+	definitionRequest := fwfunction.DefinitionRequest{}
+	definitionResponse := &fwfunction.DefinitionResponse{}
+
+	provider.NewNormalizeEmailFunction().Definition(ctx, definitionRequest, definitionResponse)
+
+	if definitionResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition method diagnostics: %+v", definitionResponse.Diagnostics)
+	}
+
+	validateResponse := &fwfunction.DefinitionValidateResponse{}
+	definitionResponse.Definition.ValidateImplementation(ctx, fwfunction.DefinitionValidateRequest{FuncName: "normalize_email"}, validateResponse)
+	if validateResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition validation diagnostics: %+v", validateResponse.Diagnostics)
+	}
+}
+
+func TestNormalizeEmailFunction_Success(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  string
+	}{
+		"ascii": {
+			input: "info@example.com",
+			want:  "info@example.com",
+		},
+		"uppercase": {
+			input: "Info@Example.COM",
+			want:  "info@example.com",
+		},
+		"whitespace": {
+			input: " info@example.com ",
+			want:  "info@example.com",
+		},
+		"idna": {
+			input: "info@hoß.de",
+			want:  "info@xn--ho-hia.de",
+		},
+		"idna-punycode": {
+			input: "info@xn--ho-hia.de",
+			want:  "info@xn--ho-hia.de",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::migadu::normalize_email("%s")
+							}
+						`, testCase.input),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckOutput("test", testCase.want),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestNormalizeEmailFunction_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  string
+	}{
+		"missing-at": {
+			input: `info`,
+			want:  `An email must match the format 'local_part@domain'`,
+		},
+		"missing-local-part": {
+			input: `@example.com`,
+			want:  `An email must match the format 'local_part@domain'`,
+		},
+		"missing-domain": {
+			input: `info@`,
+			want:  `An email must match the format 'local_part@domain'`,
+		},
+		"multiple-at": {
+			input: `a@b@example.com`,
+			want:  `An email must match the format 'local_part@domain'`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::migadu::normalize_email("%s")
+							}
+						`, testCase.input),
+						ExpectError: regexp.MustCompile(testCase.want),
+					},
+				},
+			})
+		})
+	}
+}
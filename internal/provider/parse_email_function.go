@@ -0,0 +1,74 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = (*ParseEmailFunction)(nil)
+)
+
+func NewParseEmailFunction() function.Function {
+	return &ParseEmailFunction{}
+}
+
+type ParseEmailFunction struct{}
+
+type ParseEmailFunctionResult struct {
+	LocalPart types.String `tfsdk:"local_part"`
+	Domain    types.String `tfsdk:"domain"`
+}
+
+func (f *ParseEmailFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "parse_email"
+}
+
+func (f *ParseEmailFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Splits an email address into its local part and domain.",
+		Description:         "Splits an email address into an object with the attributes 'local_part' and 'domain'. Both values are returned as given, use 'normalize_email' first in order to get normalized values.",
+		MarkdownDescription: "Splits an email address into an object with the attributes `local_part` and `domain`. Both values are returned as given, use `normalize_email` first in order to get normalized values.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "email",
+				Description:         "The email address to parse.",
+				MarkdownDescription: "The email address to parse.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"local_part": types.StringType,
+				"domain":     types.StringType,
+			},
+		},
+	}
+}
+
+func (f *ParseEmailFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var email string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &email))
+	if response.Error != nil {
+		return
+	}
+
+	localPart, domain, funcErr := splitEmailArgument(0, email)
+	if funcErr != nil {
+		response.Error = funcErr
+		return
+	}
+
+	result := ParseEmailFunctionResult{
+		LocalPart: types.StringValue(localPart),
+		Domain:    types.StringValue(domain),
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, result))
+}
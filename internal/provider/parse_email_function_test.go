@@ -0,0 +1,130 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwfunction "github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"regexp"
+	"testing"
+)
+
+func TestParseEmailFunction_Definition(t *testing.T) {
+	ctx := context.Background()
+	definitionRequest := fwfunction.DefinitionRequest{}
+	definitionResponse := &fwfunction.DefinitionResponse{}
+
+	provider.NewParseEmailFunction().Definition(ctx, definitionRequest, definitionResponse)
+
+	if definitionResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition method diagnostics: %+v", definitionResponse.Diagnostics)
+	}
+
+	validateResponse := &fwfunction.DefinitionValidateResponse{}
+	definitionResponse.Definition.ValidateImplementation(ctx, fwfunction.DefinitionValidateRequest{FuncName: "parse_email"}, validateResponse)
+	if validateResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition validation diagnostics: %+v", validateResponse.Diagnostics)
+	}
+}
+
+func TestParseEmailFunction_Success(t *testing.T) {
+	testCases := map[string]struct {
+		input     string
+		localPart string
+		domain    string
+	}{
+		"ascii": {
+			input:     "info@example.com",
+			localPart: "info",
+			domain:    "example.com",
+		},
+		"idna": {
+			input:     "info@hoß.de",
+			localPart: "info",
+			domain:    "hoß.de",
+		},
+		"idna-punycode": {
+			input:     "info@xn--ho-hia.de",
+			localPart: "info",
+			domain:    "xn--ho-hia.de",
+		},
+		"uppercase": {
+			input:     "Info@Example.COM",
+			localPart: "Info",
+			domain:    "Example.COM",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "local_part" {
+								value = provider::migadu::parse_email("%s").local_part
+							}
+							output "domain" {
+								value = provider::migadu::parse_email("%s").domain
+							}
+						`, testCase.input, testCase.input),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckOutput("local_part", testCase.localPart),
+							resource.TestCheckOutput("domain", testCase.domain),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestParseEmailFunction_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  string
+	}{
+		"missing-at": {
+			input: `info`,
+			want:  `An email must match the format 'local_part@domain'`,
+		},
+		"missing-local-part": {
+			input: `@example.com`,
+			want:  `An email must match the format 'local_part@domain'`,
+		},
+		"missing-domain": {
+			input: `info@`,
+			want:  `An email must match the format 'local_part@domain'`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::migadu::parse_email("%s")
+							}
+						`, testCase.input),
+						ExpectError: regexp.MustCompile(testCase.want),
+					},
+				},
+			})
+		})
+	}
+}
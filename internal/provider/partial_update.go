@@ -0,0 +1,140 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+type requestFieldsKey struct{}
+
+// WithRequestFields returns a context whose requests to the Migadu API only send the given fields of their JSON body.
+// The Migadu client always sends all fields of an object, which reverts changes made outside of Terraform, e.g. an
+// automatic response enabled in the webmail, whenever an unrelated attribute changes.
+func WithRequestFields(ctx context.Context, fields ...string) context.Context {
+	return context.WithValue(ctx, requestFieldsKey{}, fields)
+}
+
+// NewRequestFieldsTransport wraps the given transport and removes all fields from JSON request bodies that were not
+// selected with WithRequestFields. Requests without selected fields pass through unchanged.
+func NewRequestFieldsTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &requestFieldsTransport{
+		next: next,
+	}
+}
+
+type requestFieldsTransport struct {
+	next http.RoundTripper
+}
+
+func (t *requestFieldsTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	fields, ok := request.Context().Value(requestFieldsKey{}).([]string)
+	if !ok || request.Body == nil || request.Body == http.NoBody {
+		return t.next.RoundTrip(request)
+	}
+
+	body, err := io.ReadAll(request.Body)
+	closeErr := request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if closeErr != nil {
+		return nil, closeErr
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err == nil {
+		selected := make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			if value, ok := object[field]; ok {
+				selected[field] = value
+			}
+		}
+		body, err = json.Marshal(selected)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	request = request.Clone(request.Context())
+	request.Body = io.NopCloser(bytes.NewReader(body))
+	request.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	request.ContentLength = int64(len(body))
+	return t.next.RoundTrip(request)
+}
+
+// requestFields collects the JSON fields of an API object that are sent to the Migadu API, see WithRequestFields.
+type requestFields struct {
+	object reflect.Value
+	names  []string
+}
+
+// newRequestFields returns an empty selection of the fields of the given pointer to an API object.
+func newRequestFields(object any) *requestFields {
+	return &requestFields{
+		object: reflect.ValueOf(object).Elem(),
+	}
+}
+
+// add selects the given field of the API object in case its planned value changed. Unknown planned values are left
+// to the API.
+func (f *requestFields) add(field any, plan attr.Value, state attr.Value) {
+	if plan.IsUnknown() || plan.Equal(state) {
+		return
+	}
+	f.addAlways(field)
+}
+
+// addKnown selects the given field of the API object in case its planned value is known.
+func (f *requestFields) addKnown(field any, plan attr.Value) {
+	if plan.IsUnknown() {
+		return
+	}
+	f.addAlways(field)
+}
+
+// addAlways selects the given field of the API object. The field is given by its address, and its name is taken from
+// the JSON tag of the API object.
+func (f *requestFields) addAlways(field any) {
+	pointer := reflect.ValueOf(field)
+	for index := range f.object.NumField() {
+		if candidate := f.object.Field(index).Addr(); candidate.Type() != pointer.Type() || candidate.Pointer() != pointer.Pointer() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.object.Type().Field(index).Tag.Get("json"), ",")
+		for _, selected := range f.names {
+			if selected == name {
+				return
+			}
+		}
+		f.names = append(f.names, name)
+		return
+	}
+	panic(fmt.Sprintf("%T is not a field of %s", field, f.object.Type()))
+}
+
+// empty returns whether no field is selected.
+func (f *requestFields) empty() bool {
+	return len(f.names) == 0
+}
+
+// context returns a context whose requests only send the selected fields.
+func (f *requestFields) context(ctx context.Context) context.Context {
+	return WithRequestFields(ctx, f.names...)
+}
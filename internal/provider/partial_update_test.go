@@ -0,0 +1,112 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	internal "github.com/metio/terraform-provider-migadu/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestFieldsTransport(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		ctx      context.Context
+		body     string
+		wantBody string
+	}{
+		"selected-fields": {
+			ctx:      internal.WithRequestFields(context.Background(), "name", "may_send"),
+			body:     `{"name":"Some Name","may_send":false,"may_receive":true,"delegations":null}`,
+			wantBody: `{"name":"Some Name","may_send":false}`,
+		},
+		"missing-fields": {
+			ctx:      internal.WithRequestFields(context.Background(), "name", "footer_plain_body"),
+			body:     `{"name":"Some Name","footer_active":false}`,
+			wantBody: `{"name":"Some Name"}`,
+		},
+		"nested-values": {
+			ctx:      internal.WithRequestFields(context.Background(), "destinations"),
+			body:     `{"destinations":["some@example.com","other@example.com"],"is_internal":false}`,
+			wantBody: `{"destinations":["some@example.com","other@example.com"]}`,
+		},
+		"without-selection": {
+			ctx:      context.Background(),
+			body:     `{"name":"Some Name","may_send":false}`,
+			wantBody: `{"name":"Some Name","may_send":false}`,
+		},
+		"not-an-object": {
+			ctx:      internal.WithRequestFields(context.Background(), "name"),
+			body:     `["Some Name"]`,
+			wantBody: `["Some Name"]`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err, "ReadAll")
+				assert.JSONEq(t, testCase.wantBody, string(body), "Body")
+				assert.Equal(t, int64(len(body)), r.ContentLength, "ContentLength")
+				_ = json.NewEncoder(w).Encode(map[string]string{})
+			}))
+			defer server.Close()
+
+			httpClient := &http.Client{Transport: internal.NewRequestFieldsTransport(server.Client().Transport)}
+			request, err := http.NewRequestWithContext(testCase.ctx, http.MethodPut, server.URL, strings.NewReader(testCase.body))
+			assert.NoError(t, err, "NewRequest")
+
+			response, err := httpClient.Do(request)
+			if assert.NoError(t, err, "Do") {
+				_ = response.Body.Close()
+				assert.Equal(t, http.StatusOK, response.StatusCode, "StatusCode")
+			}
+		})
+	}
+}
+
+func TestRequestFieldsTransport_Retry(t *testing.T) {
+	t.Parallel()
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{})
+	}))
+	defer server.Close()
+
+	transport := internal.NewRequestFieldsTransport(internal.NewRetryTransport(server.Client().Transport, internal.RetrySettings{
+		MaxRetries: 1,
+	}))
+	httpClient := &http.Client{Transport: transport}
+	ctx := internal.WithRequestFields(context.Background(), "name")
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, server.URL, strings.NewReader(`{"name":"Some Name","may_send":false}`))
+	assert.NoError(t, err, "NewRequest")
+
+	response, err := httpClient.Do(request)
+	if assert.NoError(t, err, "Do") {
+		_ = response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode, "StatusCode")
+	}
+	if assert.Len(t, bodies, 2, "requests") {
+		assert.JSONEq(t, `{"name":"Some Name"}`, bodies[0], "first attempt")
+		assert.JSONEq(t, `{"name":"Some Name"}`, bodies[1], "second attempt")
+	}
+}
@@ -0,0 +1,122 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"math/big"
+	"strings"
+)
+
+const (
+	passwordLowerCharacters   = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpperCharacters   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordNumericCharacters = "0123456789"
+	passwordSpecialCharacters = "!#$%&*+-=?@^_"
+	passphraseConsonants      = "bcdfghjklmnprstvwxz"
+	passphraseVowels          = "aeiou"
+)
+
+// generatePassword returns a random password of the given length that contains at least one character of every given
+// character class.
+func generatePassword(length int, classes []string) (string, error) {
+	if len(classes) == 0 {
+		return "", errors.New("at least one character class is required")
+	}
+	if length < len(classes) {
+		return "", fmt.Errorf("length %d is too short to contain all %d character classes", length, len(classes))
+	}
+
+	password := make([]byte, 0, length)
+	for _, class := range classes {
+		character, err := randomCharacter(class)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, character)
+	}
+
+	pool := strings.Join(classes, "")
+	for len(password) < length {
+		character, err := randomCharacter(pool)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, character)
+	}
+
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+
+	return string(password), nil
+}
+
+// generatePassphrase returns a random passphrase of pronounceable words made of three syllables each. The first word is
+// capitalized and a random digit is appended in order to satisfy password policies that require these character classes.
+func generatePassphrase(words int, separator string) (string, error) {
+	if words < 1 {
+		return "", errors.New("at least one word is required")
+	}
+
+	passphrase := make([]string, 0, words)
+	for range words {
+		var word strings.Builder
+		for range 3 {
+			consonant, err := randomCharacter(passphraseConsonants)
+			if err != nil {
+				return "", err
+			}
+			vowel, err := randomCharacter(passphraseVowels)
+			if err != nil {
+				return "", err
+			}
+			word.WriteByte(consonant)
+			word.WriteByte(vowel)
+		}
+		passphrase = append(passphrase, word.String())
+	}
+	passphrase[0] = strings.ToUpper(passphrase[0][:1]) + passphrase[0][1:]
+
+	digit, err := randomCharacter(passwordNumericCharacters)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join(passphrase, separator) + string(digit), nil
+}
+
+func randomCharacter(characters string) (byte, error) {
+	index, err := randomInt(len(characters))
+	if err != nil {
+		return 0, err
+	}
+	return characters[index], nil
+}
+
+func randomInt(upperBound int) (int, error) {
+	value, err := rand.Int(rand.Reader, big.NewInt(int64(upperBound)))
+	if err != nil {
+		return 0, err
+	}
+	return int(value.Int64()), nil
+}
+
+func PasswordGenerateError(err error) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Generating Password",
+		"While generating a random password, an unexpected error occurred. "+
+			"Please report this issue to the provider developers.\n\n"+
+			"Error: "+err.Error(),
+	)
+}
@@ -0,0 +1,227 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ ephemeral.EphemeralResource                   = (*PasswordEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithValidateConfig = (*PasswordEphemeralResource)(nil)
+)
+
+func NewPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &PasswordEphemeralResource{}
+}
+
+type PasswordEphemeralResource struct{}
+
+type PasswordEphemeralResourceModel struct {
+	Length     types.Int64  `tfsdk:"length"`
+	Lower      types.Bool   `tfsdk:"lower"`
+	Upper      types.Bool   `tfsdk:"upper"`
+	Numeric    types.Bool   `tfsdk:"numeric"`
+	Special    types.Bool   `tfsdk:"special"`
+	Passphrase types.Bool   `tfsdk:"passphrase"`
+	Words      types.Int64  `tfsdk:"words"`
+	Separator  types.String `tfsdk:"separator"`
+	Result     types.String `tfsdk:"result"`
+}
+
+func (e *PasswordEphemeralResource) Metadata(_ context.Context, request ephemeral.MetadataRequest, response *ephemeral.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_password"
+}
+
+func (e *PasswordEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, response *ephemeral.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Generates a random password for mailboxes and identities without storing it in the state. Use its result in write-only attributes like 'password_wo' or store it in an external secret store. Requires Terraform 1.10 or later.",
+		MarkdownDescription: "Generates a random password for mailboxes and identities without storing it in the state. Use its result in write-only attributes like `password_wo` or store it in an external secret store. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"length": schema.Int64Attribute{
+				Description:         "The length of the password. Ignored in passphrase mode. Defaults to '32'.",
+				MarkdownDescription: "The length of the password. Ignored in passphrase mode. Defaults to `32`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+				Validators: []validator.Int64{
+					int64validator.Between(8, 1024),
+				},
+			},
+			"lower": schema.BoolAttribute{
+				Description:         "Whether the password contains at least one lowercase letter. Defaults to 'true'.",
+				MarkdownDescription: "Whether the password contains at least one lowercase letter. Defaults to `true`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+			},
+			"upper": schema.BoolAttribute{
+				Description:         "Whether the password contains at least one uppercase letter. Defaults to 'true'.",
+				MarkdownDescription: "Whether the password contains at least one uppercase letter. Defaults to `true`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+			},
+			"numeric": schema.BoolAttribute{
+				Description:         "Whether the password contains at least one digit. Defaults to 'true'.",
+				MarkdownDescription: "Whether the password contains at least one digit. Defaults to `true`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+			},
+			"special": schema.BoolAttribute{
+				Description:         "Whether the password contains at least one of the special characters '!#$%&*+-=?@^_'. Defaults to 'true'.",
+				MarkdownDescription: "Whether the password contains at least one of the special characters `!#$%&*+-=?@^_`. Defaults to `true`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+			},
+			"passphrase": schema.BoolAttribute{
+				Description:         "Whether to generate a passphrase of random pronounceable words instead of a password. The first word is capitalized and a digit is appended. Defaults to 'false'.",
+				MarkdownDescription: "Whether to generate a passphrase of random pronounceable words instead of a password. The first word is capitalized and a digit is appended. Defaults to `false`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+			},
+			"words": schema.Int64Attribute{
+				Description:         "The number of words in passphrase mode. Defaults to '6'.",
+				MarkdownDescription: "The number of words in passphrase mode. Defaults to `6`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+				Validators: []validator.Int64{
+					int64validator.Between(4, 64),
+				},
+			},
+			"separator": schema.StringAttribute{
+				Description:         "The separator between words in passphrase mode. Defaults to '-'.",
+				MarkdownDescription: "The separator between words in passphrase mode. Defaults to `-`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+				Validators: []validator.String{
+					stringvalidator.LengthAtMost(8),
+				},
+			},
+			"result": schema.StringAttribute{
+				Description:         "The generated password.",
+				MarkdownDescription: "The generated password.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (e *PasswordEphemeralResource) ValidateConfig(ctx context.Context, request ephemeral.ValidateConfigRequest, response *ephemeral.ValidateConfigResponse) {
+	var config PasswordEphemeralResourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &config)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Passphrase.ValueBool() {
+		return
+	}
+
+	classes := []types.Bool{config.Lower, config.Upper, config.Numeric, config.Special}
+	for _, class := range classes {
+		if class.IsNull() || class.IsUnknown() || class.ValueBool() {
+			return
+		}
+	}
+
+	response.Diagnostics.AddAttributeError(
+		path.Root("lower"),
+		"Invalid Attribute Combination",
+		"At least one of 'lower', 'upper', 'numeric', or 'special' must be enabled",
+	)
+}
+
+func (e *PasswordEphemeralResource) Open(ctx context.Context, request ephemeral.OpenRequest, response *ephemeral.OpenResponse) {
+	var data PasswordEphemeralResourceModel
+	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	var result string
+	var err error
+	if data.Passphrase.ValueBool() {
+		words := int64(6)
+		if !data.Words.IsNull() {
+			words = data.Words.ValueInt64()
+		}
+		separator := "-"
+		if !data.Separator.IsNull() {
+			separator = data.Separator.ValueString()
+		}
+		// validators do not run for values that were unknown while validating the configuration
+		if words < 4 || words > 64 {
+			response.Diagnostics.AddAttributeError(
+				path.Root("words"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("Attribute words value must be between 4 and 64, got: %d", words),
+			)
+			return
+		}
+		result, err = generatePassphrase(int(words), separator)
+	} else {
+		length := int64(32)
+		if !data.Length.IsNull() {
+			length = data.Length.ValueInt64()
+		}
+		if length < 8 || length > 1024 {
+			response.Diagnostics.AddAttributeError(
+				path.Root("length"),
+				"Invalid Attribute Value",
+				fmt.Sprintf("Attribute length value must be between 8 and 1024, got: %d", length),
+			)
+			return
+		}
+		var classes []string
+		for _, class := range []struct {
+			enabled    types.Bool
+			characters string
+		}{
+			{enabled: data.Lower, characters: passwordLowerCharacters},
+			{enabled: data.Upper, characters: passwordUpperCharacters},
+			{enabled: data.Numeric, characters: passwordNumericCharacters},
+			{enabled: data.Special, characters: passwordSpecialCharacters},
+		} {
+			if class.enabled.IsNull() || class.enabled.ValueBool() {
+				classes = append(classes, class.characters)
+			}
+		}
+		if len(classes) == 0 {
+			response.Diagnostics.AddAttributeError(
+				path.Root("lower"),
+				"Invalid Attribute Combination",
+				"At least one of 'lower', 'upper', 'numeric', or 'special' must be enabled",
+			)
+			return
+		}
+		result, err = generatePassword(int(length), classes)
+	}
+	if err != nil {
+		response.Diagnostics.Append(PasswordGenerateError(err))
+		return
+	}
+
+	data.Result = types.StringValue(result)
+
+	response.Diagnostics.Append(response.Result.Set(ctx, &data)...)
+}
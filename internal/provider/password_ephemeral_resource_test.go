@@ -0,0 +1,309 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwephemeral "github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var (
+	testAccProtoV6ProviderFactoriesWithEcho = map[string]func() (tfprotov6.ProviderServer, error){
+		"migadu": providerserver.NewProtocol6WithError(provider.New()),
+		"echo":   echoprovider.NewProviderServer(),
+	}
+)
+
+func TestPasswordEphemeralResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwephemeral.SchemaRequest{}
+	schemaResponse := &fwephemeral.SchemaResponse{}
+
+	provider.NewPasswordEphemeralResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestPasswordEphemeralResource_Success(t *testing.T) {
+	testCases := map[string]struct {
+		configuration string
+		want          *regexp.Regexp
+	}{
+		"defaults": {
+			configuration: ``,
+			want:          regexp.MustCompile(`^[a-zA-Z0-9!#$%&*+\-=?@^_]{32}$`),
+		},
+		"length": {
+			configuration: `
+				length = 12
+			`,
+			want: regexp.MustCompile(`^[a-zA-Z0-9!#$%&*+\-=?@^_]{12}$`),
+		},
+		"only-lower": {
+			configuration: `
+				upper   = false
+				numeric = false
+				special = false
+			`,
+			want: regexp.MustCompile(`^[a-z]{32}$`),
+		},
+		"no-special": {
+			configuration: `
+				length  = 16
+				special = false
+			`,
+			want: regexp.MustCompile(`^[a-zA-Z0-9]{16}$`),
+		},
+		"passphrase": {
+			configuration: `
+				passphrase = true
+			`,
+			want: regexp.MustCompile(`^[A-Z][a-z]{5}(-[a-z]{6}){5}[0-9]$`),
+		},
+		"passphrase-custom": {
+			configuration: `
+				passphrase = true
+				words      = 4
+				separator  = "."
+			`,
+			want: regexp.MustCompile(`^[A-Z][a-z]{5}(\.[a-z]{6}){3}[0-9]$`),
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_10_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							ephemeral "migadu_password" "test" {
+								%s
+							}
+
+							provider "echo" {
+								data = ephemeral.migadu_password.test.result
+							}
+
+							resource "echo" "test" {}
+						`, testCase.configuration),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestMatchResourceAttr("echo.test", "data", testCase.want),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestPasswordEphemeralResource_Success_Character_Classes(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig("https://localhost:12345") + `
+					ephemeral "migadu_password" "test" {
+						length = 8
+					}
+
+					provider "echo" {
+						data = ephemeral.migadu_password.test.result
+					}
+
+					resource "echo" "test" {}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("echo.test", "data", func(value string) error {
+						for _, class := range []string{"abcdefghijklmnopqrstuvwxyz", "ABCDEFGHIJKLMNOPQRSTUVWXYZ", "0123456789", "!#$%&*+-=?@^_"} {
+							if !strings.ContainsAny(value, class) {
+								return fmt.Errorf("password %q contains none of %q", value, class)
+							}
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestPasswordEphemeralResource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"too-short": {
+			Configuration: `
+				length = 4
+			`,
+			ErrorRegex: "Attribute length value must be between 8 and 1024",
+		},
+		"too-few-words": {
+			Configuration: `
+				passphrase = true
+				words      = 2
+			`,
+			ErrorRegex: "Attribute words value must be between 4 and 64",
+		},
+		"no-character-classes": {
+			Configuration: `
+				lower   = false
+				upper   = false
+				numeric = false
+				special = false
+			`,
+			ErrorRegex: "At least one of 'lower', 'upper', 'numeric', or 'special' must be enabled",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactoriesWithEcho,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_10_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							ephemeral "migadu_password" "test" {
+								%s
+							}
+
+							provider "echo" {
+								data = ephemeral.migadu_password.test.result
+							}
+
+							resource "echo" "test" {}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestPasswordEphemeralResource_Open_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		values        map[string]tftypes.Value
+		wantAttribute path.Path
+	}{
+		"no-character-classes": {
+			values: map[string]tftypes.Value{
+				"lower":   tftypes.NewValue(tftypes.Bool, false),
+				"upper":   tftypes.NewValue(tftypes.Bool, false),
+				"numeric": tftypes.NewValue(tftypes.Bool, false),
+				"special": tftypes.NewValue(tftypes.Bool, false),
+			},
+			wantAttribute: path.Root("lower"),
+		},
+		"no-words": {
+			values: map[string]tftypes.Value{
+				"passphrase": tftypes.NewValue(tftypes.Bool, true),
+				"words":      tftypes.NewValue(tftypes.Number, 0),
+			},
+			wantAttribute: path.Root("words"),
+		},
+		"too-few-words": {
+			values: map[string]tftypes.Value{
+				"passphrase": tftypes.NewValue(tftypes.Bool, true),
+				"words":      tftypes.NewValue(tftypes.Number, 3),
+			},
+			wantAttribute: path.Root("words"),
+		},
+		"too-many-words": {
+			values: map[string]tftypes.Value{
+				"passphrase": tftypes.NewValue(tftypes.Bool, true),
+				"words":      tftypes.NewValue(tftypes.Number, 65),
+			},
+			wantAttribute: path.Root("words"),
+		},
+		"negative-length": {
+			values: map[string]tftypes.Value{
+				"length": tftypes.NewValue(tftypes.Number, -1),
+			},
+			wantAttribute: path.Root("length"),
+		},
+		"too-short": {
+			values: map[string]tftypes.Value{
+				"length": tftypes.NewValue(tftypes.Number, 3),
+			},
+			wantAttribute: path.Root("length"),
+		},
+		"too-long": {
+			values: map[string]tftypes.Value{
+				"length": tftypes.NewValue(tftypes.Number, 1025),
+			},
+			wantAttribute: path.Root("length"),
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			e := provider.NewPasswordEphemeralResource()
+			schemaResponse := &fwephemeral.SchemaResponse{}
+			e.Schema(ctx, fwephemeral.SchemaRequest{}, schemaResponse)
+
+			objectType := schemaResponse.Schema.Type().TerraformType(ctx).(tftypes.Object)
+			attributes := map[string]tftypes.Value{}
+			for name, attributeType := range objectType.AttributeTypes {
+				if value, ok := testCase.values[name]; ok {
+					attributes[name] = value
+				} else {
+					attributes[name] = tftypes.NewValue(attributeType, nil)
+				}
+			}
+			request := fwephemeral.OpenRequest{
+				Config: tfsdk.Config{
+					Schema: schemaResponse.Schema,
+					Raw:    tftypes.NewValue(objectType, attributes),
+				},
+			}
+			response := &fwephemeral.OpenResponse{
+				Result: tfsdk.EphemeralResultData{
+					Schema: schemaResponse.Schema,
+					Raw:    tftypes.NewValue(objectType, nil),
+				},
+			}
+
+			e.Open(ctx, request, response)
+
+			if assert.Equal(t, 1, response.Diagnostics.ErrorsCount(), "errors: %+v", response.Diagnostics) {
+				got := response.Diagnostics.Errors()[0].(diag.DiagnosticWithPath)
+				assert.Equal(t, testCase.wantAttribute, got.Path(), "Path")
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGeneratePassword_Errors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		length  int
+		classes []string
+	}{
+		"no-classes": {
+			length:  32,
+			classes: nil,
+		},
+		"shorter-than-classes": {
+			length:  3,
+			classes: []string{passwordLowerCharacters, passwordUpperCharacters, passwordNumericCharacters, passwordSpecialCharacters},
+		},
+		"negative-length": {
+			length:  -1,
+			classes: []string{passwordLowerCharacters},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := generatePassword(testCase.length, testCase.classes)
+
+			assert.Error(t, err)
+			assert.Empty(t, got)
+		})
+	}
+}
@@ -7,33 +7,67 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/metio/migadu-client.go/client"
+	"net/url"
 	"os"
 	"strconv"
 	"time"
 )
 
 var (
-	_ provider.Provider = (*MigaduProvider)(nil)
+	_ provider.Provider                       = (*MigaduProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*MigaduProvider)(nil)
+	_ provider.ProviderWithFunctions          = (*MigaduProvider)(nil)
+	_ provider.ProviderWithListResources      = (*MigaduProvider)(nil)
 )
 
 type MigaduProvider struct{}
 
 type MigaduProviderModel struct {
-	Endpoint     types.String `tfsdk:"endpoint"`
-	Token        types.String `tfsdk:"token"`
-	Username     types.String `tfsdk:"username"`
-	Timeout      types.Int64  `tfsdk:"timeout"`
-	RateLimit    types.Int64  `tfsdk:"rate_limit"`
-	RateInterval types.String `tfsdk:"rate_interval"`
+	Endpoint              types.String `tfsdk:"endpoint"`
+	Token                 types.String `tfsdk:"token"`
+	TokenFile             types.String `tfsdk:"token_file"`
+	TokenCommand          types.String `tfsdk:"token_command"`
+	Profile               types.String `tfsdk:"profile"`
+	Username              types.String `tfsdk:"username"`
+	Timeout               types.Int64  `tfsdk:"timeout"`
+	RateLimit             types.Int64  `tfsdk:"rate_limit"`
+	RateInterval          types.String `tfsdk:"rate_interval"`
+	MaxConcurrentRequests types.Int64  `tfsdk:"max_concurrent_requests"`
+	MaxRetries            types.Int64  `tfsdk:"max_retries"`
+	RetryMinBackoff       types.String `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff       types.String `tfsdk:"retry_max_backoff"`
+	ReadCache             types.Bool   `tfsdk:"read_cache"`
+	ValidateCredentials   types.Bool   `tfsdk:"validate_credentials"`
+	AdoptExisting         types.Bool   `tfsdk:"adopt_existing"`
+	ProxyURL              types.String `tfsdk:"proxy_url"`
+	CABundleFile          types.String `tfsdk:"ca_bundle_file"`
+	CABundlePEM           types.String `tfsdk:"ca_bundle_pem"`
+	ClientCert            types.String `tfsdk:"client_cert"`
+	ClientKey             types.String `tfsdk:"client_key"`
+	InsecureSkipVerify    types.Bool   `tfsdk:"insecure_skip_verify"`
+}
+
+// MigaduProviderData is passed to all resources of this provider.
+type MigaduProviderData struct {
+	Client        *client.MigaduClient
+	ReadCache     *ReadCache
+	AdoptExisting bool
 }
 
 func New() provider.Provider {
@@ -59,6 +93,30 @@ func (p *MigaduProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				MarkdownDescription: "The API key to use. Can be specified with the `MIGADU_TOKEN` environment variable. Take a look at https://www.migadu.com/api/#api-keys for more information.",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token_file"), path.MatchRoot("token_command")),
+				},
+			},
+			"token_file": schema.StringAttribute{
+				Description:         "The path to a file containing the API key to use. Leading and trailing whitespace is ignored. Can be specified with the 'MIGADU_TOKEN_FILE' environment variable.",
+				MarkdownDescription: "The path to a file containing the API key to use. Leading and trailing whitespace is ignored. Can be specified with the `MIGADU_TOKEN_FILE` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token"), path.MatchRoot("token_command")),
+				},
+			},
+			"token_command": schema.StringAttribute{
+				Description:         "A command printing the API key to use on its standard output, e.g. 'pass show migadu'. The command is run by 'sh -c' or 'cmd /C' on Windows. Can be specified with the 'MIGADU_TOKEN_COMMAND' environment variable.",
+				MarkdownDescription: "A command printing the API key to use on its standard output, e.g. `pass show migadu`. The command is run by `sh -c` or `cmd /C` on Windows. Can be specified with the `MIGADU_TOKEN_COMMAND` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("token"), path.MatchRoot("token_file")),
+				},
+			},
+			"profile": schema.StringAttribute{
+				Description:         "The name of the profile to read the username and API key from. Profiles are stored in the shared credentials file at '~/.config/migadu/credentials' or '$XDG_CONFIG_HOME/migadu/credentials'. Can be specified with the 'MIGADU_PROFILE' environment variable. The username and API key are each taken from the first of the following sources that provides them: the 'username', 'token', 'token_file' and 'token_command' attributes, this profile, the 'MIGADU_USERNAME', 'MIGADU_TOKEN', 'MIGADU_TOKEN_FILE' and 'MIGADU_TOKEN_COMMAND' environment variables, and the profile of the 'MIGADU_PROFILE' environment variable. The shared credentials file is only read in case a profile is named.",
+				MarkdownDescription: "The name of the profile to read the username and API key from. Profiles are stored in the shared credentials file at `~/.config/migadu/credentials` or `$XDG_CONFIG_HOME/migadu/credentials`. Can be specified with the `MIGADU_PROFILE` environment variable. The username and API key are each taken from the first of the following sources that provides them: the `username`, `token`, `token_file` and `token_command` attributes, this profile, the `MIGADU_USERNAME`, `MIGADU_TOKEN`, `MIGADU_TOKEN_FILE` and `MIGADU_TOKEN_COMMAND` environment variables, and the profile of the `MIGADU_PROFILE` environment variable. The shared credentials file is only read in case a profile is named.",
+				Optional:            true,
 			},
 			"username": schema.StringAttribute{
 				Description:         "The username to use. Can be specified with the 'MIGADU_USERNAME' environment variable. Take a look at https://www.migadu.com/api/#api-requests for more information.",
@@ -67,13 +125,13 @@ func (p *MigaduProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Sensitive:           true,
 			},
 			"timeout": schema.Int64Attribute{
-				Description:         "The timeout to apply for HTTP requests in seconds. Can be specified with the 'MIGADU_TIMEOUT' environment variable. Defaults to '10'.",
-				MarkdownDescription: "The timeout to apply for HTTP requests in seconds. Can be specified with the `MIGADU_TIMEOUT` environment variable. Defaults to `10`.",
+				Description:         "The timeout to apply for HTTP requests in seconds. Applies to each attempt separately when requests are retried. Can be specified with the 'MIGADU_TIMEOUT' environment variable. Defaults to '10'.",
+				MarkdownDescription: "The timeout to apply for HTTP requests in seconds. Applies to each attempt separately when requests are retried. Can be specified with the `MIGADU_TIMEOUT` environment variable. Defaults to `10`.",
 				Optional:            true,
 			},
 			"rate_limit": schema.Int64Attribute{
-				Description:         "The maximum number of API requests allowed per 'rate_interval'. Can be specified with the 'MIGADU_RATE_LIMIT' environment variable. Defaults to '60'. Set to '0' to disable client-side rate limiting.",
-				MarkdownDescription: "The maximum number of API requests allowed per `rate_interval`. Can be specified with the `MIGADU_RATE_LIMIT` environment variable. Defaults to `60`. Set to `0` to disable client-side rate limiting.",
+				Description:         "The maximum number of API requests allowed per 'rate_interval'. The limit applies to all provider configurations that use the same 'endpoint' and 'username'. Can be specified with the 'MIGADU_RATE_LIMIT' environment variable. Defaults to '60'. Set to '0' to disable client-side rate limiting.",
+				MarkdownDescription: "The maximum number of API requests allowed per `rate_interval`. The limit applies to all provider configurations that use the same `endpoint` and `username`. Can be specified with the `MIGADU_RATE_LIMIT` environment variable. Defaults to `60`. Set to `0` to disable client-side rate limiting.",
 				Optional:            true,
 			},
 			"rate_interval": schema.StringAttribute{
@@ -81,6 +139,72 @@ func (p *MigaduProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				MarkdownDescription: "The interval over which `rate_limit` requests are allowed, as a Go duration string (e.g. `2m`, `30s`). Can be specified with the `MIGADU_RATE_INTERVAL` environment variable. Defaults to `2m`.",
 				Optional:            true,
 			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Description:         "The maximum number of API requests in flight at the same time. The limit applies to all provider configurations that use the same 'endpoint' and 'username'. Can be specified with the 'MIGADU_MAX_CONCURRENT_REQUESTS' environment variable. Defaults to '0' which disables the limit.",
+				MarkdownDescription: "The maximum number of API requests in flight at the same time. The limit applies to all provider configurations that use the same `endpoint` and `username`. Can be specified with the `MIGADU_MAX_CONCURRENT_REQUESTS` environment variable. Defaults to `0` which disables the limit.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description:         "The maximum number of times a failed API request is retried. Reads are retried on network errors, '429 Too Many Requests' and '5xx' responses. Creates, updates and deletes are only retried when the API did not process them, i.e. on '429 Too Many Requests' responses and when no connection could be established. Can be specified with the 'MIGADU_MAX_RETRIES' environment variable. Defaults to '3'. Set to '0' to disable retries.",
+				MarkdownDescription: "The maximum number of times a failed API request is retried. Reads are retried on network errors, `429 Too Many Requests` and `5xx` responses. Creates, updates and deletes are only retried when the API did not process them, i.e. on `429 Too Many Requests` responses and when no connection could be established. Can be specified with the `MIGADU_MAX_RETRIES` environment variable. Defaults to `3`. Set to `0` to disable retries.",
+				Optional:            true,
+			},
+			"retry_min_backoff": schema.StringAttribute{
+				Description:         "The delay before the first retry, as a Go duration string (e.g. '1s', '500ms'). The delay doubles with each further retry and is randomized to avoid concurrent requests retrying in lockstep. A longer delay requested by the API in its 'Retry-After' header takes precedence. Can be specified with the 'MIGADU_RETRY_MIN_BACKOFF' environment variable. Defaults to '1s'.",
+				MarkdownDescription: "The delay before the first retry, as a Go duration string (e.g. `1s`, `500ms`). The delay doubles with each further retry and is randomized to avoid concurrent requests retrying in lockstep. A longer delay requested by the API in its `Retry-After` header takes precedence. Can be specified with the `MIGADU_RETRY_MIN_BACKOFF` environment variable. Defaults to `1s`.",
+				Optional:            true,
+			},
+			"retry_max_backoff": schema.StringAttribute{
+				Description:         "The maximum delay between two retries, as a Go duration string (e.g. '30s', '1m'). Can be specified with the 'MIGADU_RETRY_MAX_BACKOFF' environment variable. Defaults to '30s'.",
+				MarkdownDescription: "The maximum delay between two retries, as a Go duration string (e.g. `30s`, `1m`). Can be specified with the `MIGADU_RETRY_MAX_BACKOFF` environment variable. Defaults to `30s`.",
+				Optional:            true,
+			},
+			"read_cache": schema.BoolAttribute{
+				Description:         "Whether to read aliases, mailboxes and rewrite rules of resources from a cache. The first read of a domain fetches all its aliases, mailboxes or rewrite rules in a single API request, and later reads of the same domain are answered from the cache. Changes made by this provider invalidate the cache of their domain. This considerably speeds up refreshing many resources of the same domain. Can be specified with the 'MIGADU_READ_CACHE' environment variable. Defaults to 'false'.",
+				MarkdownDescription: "Whether to read aliases, mailboxes and rewrite rules of resources from a cache. The first read of a domain fetches all its aliases, mailboxes or rewrite rules in a single API request, and later reads of the same domain are answered from the cache. Changes made by this provider invalidate the cache of their domain. This considerably speeds up refreshing many resources of the same domain. Can be specified with the `MIGADU_READ_CACHE` environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
+			"validate_credentials": schema.BoolAttribute{
+				Description:         "Whether to verify the 'endpoint', 'username' and 'token' with a single API request while configuring the provider. Wrong credentials or endpoints are then reported on the respective attribute before any resource is read. Can be specified with the 'MIGADU_VALIDATE_CREDENTIALS' environment variable. Defaults to 'false'.",
+				MarkdownDescription: "Whether to verify the `endpoint`, `username` and `token` with a single API request while configuring the provider. Wrong credentials or endpoints are then reported on the respective attribute before any resource is read. Can be specified with the `MIGADU_VALIDATE_CREDENTIALS` environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description:         "Whether mailboxes, aliases, identities and rewrite rules that already exist are adopted instead of failing to create them. Adopted objects are updated to match their configuration. Resources can override this with their own 'adopt_existing' attribute. Can be specified with the 'MIGADU_ADOPT_EXISTING' environment variable. Defaults to 'false'.",
+				MarkdownDescription: "Whether mailboxes, aliases, identities and rewrite rules that already exist are adopted instead of failing to create them. Adopted objects are updated to match their configuration. Resources can override this with their own `adopt_existing` attribute. Can be specified with the `MIGADU_ADOPT_EXISTING` environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
+			"proxy_url": schema.StringAttribute{
+				Description:         "The URL of the proxy to send all API requests through, e.g. 'http://proxy.example.com:3128'. Can be specified with the 'MIGADU_PROXY_URL' environment variable. Defaults to the proxy configured with the 'HTTPS_PROXY' and 'NO_PROXY' environment variables.",
+				MarkdownDescription: "The URL of the proxy to send all API requests through, e.g. `http://proxy.example.com:3128`. Can be specified with the `MIGADU_PROXY_URL` environment variable. Defaults to the proxy configured with the `HTTPS_PROXY` and `NO_PROXY` environment variables.",
+				Optional:            true,
+			},
+			"ca_bundle_file": schema.StringAttribute{
+				Description:         "The path to a file containing PEM encoded certificate authorities to trust in addition to those of the system, e.g. the certificate authority of a TLS-intercepting proxy. Can be specified with the 'MIGADU_CA_BUNDLE_FILE' environment variable.",
+				MarkdownDescription: "The path to a file containing PEM encoded certificate authorities to trust in addition to those of the system, e.g. the certificate authority of a TLS-intercepting proxy. Can be specified with the `MIGADU_CA_BUNDLE_FILE` environment variable.",
+				Optional:            true,
+			},
+			"ca_bundle_pem": schema.StringAttribute{
+				Description:         "PEM encoded certificate authorities to trust in addition to those of the system, e.g. the certificate authority of a TLS-intercepting proxy. Can be specified with the 'MIGADU_CA_BUNDLE_PEM' environment variable.",
+				MarkdownDescription: "PEM encoded certificate authorities to trust in addition to those of the system, e.g. the certificate authority of a TLS-intercepting proxy. Can be specified with the `MIGADU_CA_BUNDLE_PEM` environment variable.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description:         "The PEM encoded client certificate to present to the server, e.g. to authenticate against a proxy. Requires 'client_key'. Can be specified with the 'MIGADU_CLIENT_CERT' environment variable.",
+				MarkdownDescription: "The PEM encoded client certificate to present to the server, e.g. to authenticate against a proxy. Requires `client_key`. Can be specified with the `MIGADU_CLIENT_CERT` environment variable.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				Description:         "The PEM encoded private key of 'client_cert'. Can be specified with the 'MIGADU_CLIENT_KEY' environment variable.",
+				MarkdownDescription: "The PEM encoded private key of `client_cert`. Can be specified with the `MIGADU_CLIENT_KEY` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Description:         "Whether to skip the verification of the TLS certificate presented by the server. This exposes the API token to anyone able to intercept the connection and should only be used for testing. Prefer 'ca_bundle_file' or 'ca_bundle_pem' instead. Can be specified with the 'MIGADU_INSECURE_SKIP_VERIFY' environment variable. Defaults to 'false'.",
+				MarkdownDescription: "Whether to skip the verification of the TLS certificate presented by the server. This exposes the API token to anyone able to intercept the connection and should only be used for testing. Prefer `ca_bundle_file` or `ca_bundle_pem` instead. Can be specified with the `MIGADU_INSECURE_SKIP_VERIFY` environment variable. Defaults to `false`.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -121,6 +245,33 @@ func (p *MigaduProvider) Configure(ctx context.Context, request provider.Configu
 		)
 	}
 
+	if config.TokenFile.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("token_file"),
+			"Unknown Migadu API Token File",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API token file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_TOKEN_FILE environment variable.",
+		)
+	}
+
+	if config.TokenCommand.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("token_command"),
+			"Unknown Migadu API Token Command",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API token command. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_TOKEN_COMMAND environment variable.",
+		)
+	}
+
+	if config.Profile.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown Migadu API Profile",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API profile. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_PROFILE environment variable.",
+		)
+	}
+
 	if config.Timeout.IsUnknown() {
 		response.Diagnostics.AddAttributeError(
 			path.Root("timeout"),
@@ -148,29 +299,149 @@ func (p *MigaduProvider) Configure(ctx context.Context, request provider.Configu
 		)
 	}
 
+	if config.MaxConcurrentRequests.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Unknown Migadu API Max Concurrent Requests",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API max concurrent requests. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_MAX_CONCURRENT_REQUESTS environment variable.",
+		)
+	}
+
+	if config.MaxRetries.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Unknown Migadu API Max Retries",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API max retries. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_MAX_RETRIES environment variable.",
+		)
+	}
+
+	if config.RetryMinBackoff.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("retry_min_backoff"),
+			"Unknown Migadu API Retry Min Backoff",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API retry min backoff. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_RETRY_MIN_BACKOFF environment variable.",
+		)
+	}
+
+	if config.RetryMaxBackoff.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("retry_max_backoff"),
+			"Unknown Migadu API Retry Max Backoff",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API retry max backoff. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_RETRY_MAX_BACKOFF environment variable.",
+		)
+	}
+
+	if config.ReadCache.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("read_cache"),
+			"Unknown Migadu API Read Cache",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API read cache. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_READ_CACHE environment variable.",
+		)
+	}
+
+	if config.ValidateCredentials.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("validate_credentials"),
+			"Unknown Migadu API Validate Credentials",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API validate credentials. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_VALIDATE_CREDENTIALS environment variable.",
+		)
+	}
+
+	if config.AdoptExisting.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("adopt_existing"),
+			"Unknown Migadu API Adopt Existing",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API adopt existing. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_ADOPT_EXISTING environment variable.",
+		)
+	}
+
+	if config.ProxyURL.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown Migadu API Proxy URL",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API proxy URL. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_PROXY_URL environment variable.",
+		)
+	}
+
+	if config.CABundleFile.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("ca_bundle_file"),
+			"Unknown Migadu API CA Bundle File",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API CA bundle file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_CA_BUNDLE_FILE environment variable.",
+		)
+	}
+
+	if config.CABundlePEM.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("ca_bundle_pem"),
+			"Unknown Migadu API CA Bundle PEM",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API CA bundle PEM. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_CA_BUNDLE_PEM environment variable.",
+		)
+	}
+
+	if config.ClientCert.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("client_cert"),
+			"Unknown Migadu API Client Certificate",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API client certificate. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_CLIENT_CERT environment variable.",
+		)
+	}
+
+	if config.ClientKey.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("client_key"),
+			"Unknown Migadu API Client Key",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API client key. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_CLIENT_KEY environment variable.",
+		)
+	}
+
+	if config.InsecureSkipVerify.IsUnknown() {
+		response.Diagnostics.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Unknown Migadu API Insecure Skip Verify",
+			"The provider cannot create the Migadu API client as there is an unknown configuration value for the Migadu API insecure skip verify. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIGADU_INSECURE_SKIP_VERIFY environment variable.",
+		)
+	}
+
 	if response.Diagnostics.HasError() {
 		return
 	}
 
 	endpoint := os.Getenv("MIGADU_ENDPOINT")
-	username := os.Getenv("MIGADU_USERNAME")
-	token := os.Getenv("MIGADU_TOKEN")
 	timeout := os.Getenv("MIGADU_TIMEOUT")
 	rateLimit := os.Getenv("MIGADU_RATE_LIMIT")
 	rateInterval := os.Getenv("MIGADU_RATE_INTERVAL")
+	maxConcurrentRequests := os.Getenv("MIGADU_MAX_CONCURRENT_REQUESTS")
+	maxRetries := os.Getenv("MIGADU_MAX_RETRIES")
+	retryMinBackoff := os.Getenv("MIGADU_RETRY_MIN_BACKOFF")
+	retryMaxBackoff := os.Getenv("MIGADU_RETRY_MAX_BACKOFF")
+	readCache := os.Getenv("MIGADU_READ_CACHE")
+	validateCredentials := os.Getenv("MIGADU_VALIDATE_CREDENTIALS")
+	adoptExisting := os.Getenv("MIGADU_ADOPT_EXISTING")
+	proxyURL := os.Getenv("MIGADU_PROXY_URL")
+	caBundleFile := os.Getenv("MIGADU_CA_BUNDLE_FILE")
+	caBundlePEM := os.Getenv("MIGADU_CA_BUNDLE_PEM")
+	clientCert := os.Getenv("MIGADU_CLIENT_CERT")
+	clientKey := os.Getenv("MIGADU_CLIENT_KEY")
+	insecureSkipVerify := os.Getenv("MIGADU_INSECURE_SKIP_VERIFY")
 
 	if !config.Endpoint.IsNull() {
 		endpoint = config.Endpoint.ValueString()
 	}
 
-	if !config.Username.IsNull() {
-		username = config.Username.ValueString()
-	}
-
-	if !config.Token.IsNull() {
-		token = config.Token.ValueString()
-	}
-
 	if !config.Timeout.IsNull() {
 		timeout = strconv.FormatInt(config.Timeout.ValueInt64(), 10)
 	}
@@ -183,6 +454,58 @@ func (p *MigaduProvider) Configure(ctx context.Context, request provider.Configu
 		rateInterval = config.RateInterval.ValueString()
 	}
 
+	if !config.MaxConcurrentRequests.IsNull() {
+		maxConcurrentRequests = strconv.FormatInt(config.MaxConcurrentRequests.ValueInt64(), 10)
+	}
+
+	if !config.MaxRetries.IsNull() {
+		maxRetries = strconv.FormatInt(config.MaxRetries.ValueInt64(), 10)
+	}
+
+	if !config.RetryMinBackoff.IsNull() {
+		retryMinBackoff = config.RetryMinBackoff.ValueString()
+	}
+
+	if !config.RetryMaxBackoff.IsNull() {
+		retryMaxBackoff = config.RetryMaxBackoff.ValueString()
+	}
+
+	if !config.ReadCache.IsNull() {
+		readCache = strconv.FormatBool(config.ReadCache.ValueBool())
+	}
+
+	if !config.ValidateCredentials.IsNull() {
+		validateCredentials = strconv.FormatBool(config.ValidateCredentials.ValueBool())
+	}
+
+	if !config.AdoptExisting.IsNull() {
+		adoptExisting = strconv.FormatBool(config.AdoptExisting.ValueBool())
+	}
+
+	if !config.ProxyURL.IsNull() {
+		proxyURL = config.ProxyURL.ValueString()
+	}
+
+	if !config.CABundleFile.IsNull() {
+		caBundleFile = config.CABundleFile.ValueString()
+	}
+
+	if !config.CABundlePEM.IsNull() {
+		caBundlePEM = config.CABundlePEM.ValueString()
+	}
+
+	if !config.ClientCert.IsNull() {
+		clientCert = config.ClientCert.ValueString()
+	}
+
+	if !config.ClientKey.IsNull() {
+		clientKey = config.ClientKey.ValueString()
+	}
+
+	if !config.InsecureSkipVerify.IsNull() {
+		insecureSkipVerify = strconv.FormatBool(config.InsecureSkipVerify.ValueBool())
+	}
+
 	if endpoint == "" {
 		endpoint = "https://api.migadu.com/v1/"
 	}
@@ -199,23 +522,58 @@ func (p *MigaduProvider) Configure(ctx context.Context, request provider.Configu
 		rateInterval = "2m"
 	}
 
-	if username == "" {
+	if maxConcurrentRequests == "" {
+		maxConcurrentRequests = "0"
+	}
+
+	if maxRetries == "" {
+		maxRetries = "3"
+	}
+
+	if retryMinBackoff == "" {
+		retryMinBackoff = "1s"
+	}
+
+	if retryMaxBackoff == "" {
+		retryMaxBackoff = "30s"
+	}
+
+	if readCache == "" {
+		readCache = "false"
+	}
+
+	if validateCredentials == "" {
+		validateCredentials = "false"
+	}
+
+	if adoptExisting == "" {
+		adoptExisting = "false"
+	}
+
+	if insecureSkipVerify == "" {
+		insecureSkipVerify = "false"
+	}
+
+	username, token, diags := resolveCredentials(ctx, config)
+	response.Diagnostics.Append(diags...)
+
+	if username == "" && !diags.HasError() {
 		response.Diagnostics.AddAttributeError(
 			path.Root("username"),
 			"Missing Migadu API Username",
 			"The provider cannot create the Migadu API client as there is a missing or empty value for the Migadu API username. "+
-				"Set the username value in the configuration or use the MIGADU_USERNAME environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the username value in the configuration, use the MIGADU_USERNAME environment variable, or add it to the profile. "+
+				"If any of them is already set, ensure the value is not empty.",
 		)
 	}
 
-	if token == "" {
+	if token == "" && !diags.HasError() {
 		response.Diagnostics.AddAttributeError(
 			path.Root("token"),
 			"Missing Migadu API Token",
 			"The provider cannot create the Migadu API client as there is a missing or empty value for the Migadu API token. "+
-				"Set the password value in the configuration or use the MIGADU_TOKEN environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+				"Set the token, token_file or token_command value in the configuration, use the MIGADU_TOKEN, MIGADU_TOKEN_FILE or MIGADU_TOKEN_COMMAND environment variables, or add it to the profile. "+
+				"If any of them is already set, ensure the value is not empty.",
 		)
 	}
 
@@ -258,6 +616,186 @@ func (p *MigaduProvider) Configure(ctx context.Context, request provider.Configu
 		)
 	}
 
+	maxConcurrentRequestsValue, err := strconv.Atoi(maxConcurrentRequests)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Migadu API Max Concurrent Requests",
+			"The supplied max concurrent requests value cannot be parsed into an integer: "+err.Error(),
+		)
+	} else if maxConcurrentRequestsValue < 0 {
+		response.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Invalid Migadu API Max Concurrent Requests",
+			"The supplied max concurrent requests value must not be negative. Set it to '0' to disable the limit.",
+		)
+	}
+
+	maxRetriesValue, err := strconv.Atoi(maxRetries)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Migadu API Max Retries",
+			"The supplied max retries value cannot be parsed into an integer: "+err.Error(),
+		)
+	} else if maxRetriesValue < 0 {
+		response.Diagnostics.AddAttributeError(
+			path.Root("max_retries"),
+			"Invalid Migadu API Max Retries",
+			"The supplied max retries value must not be negative. Set it to '0' to disable retries.",
+		)
+	}
+
+	retryMinBackoffDuration, err := time.ParseDuration(retryMinBackoff)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			path.Root("retry_min_backoff"),
+			"Invalid Migadu API Retry Min Backoff",
+			"The supplied retry min backoff value cannot be parsed into a duration: "+err.Error(),
+		)
+	} else if retryMinBackoffDuration < 0 {
+		response.Diagnostics.AddAttributeError(
+			path.Root("retry_min_backoff"),
+			"Invalid Migadu API Retry Min Backoff",
+			"The supplied retry min backoff value must not be negative.",
+		)
+	}
+
+	retryMaxBackoffDuration, err := time.ParseDuration(retryMaxBackoff)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			path.Root("retry_max_backoff"),
+			"Invalid Migadu API Retry Max Backoff",
+			"The supplied retry max backoff value cannot be parsed into a duration: "+err.Error(),
+		)
+	} else if retryMaxBackoffDuration < retryMinBackoffDuration {
+		response.Diagnostics.AddAttributeError(
+			path.Root("retry_max_backoff"),
+			"Invalid Migadu API Retry Max Backoff",
+			"The supplied retry max backoff value must not be smaller than the retry min backoff value.",
+		)
+	}
+
+	readCacheValue, err := strconv.ParseBool(readCache)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			path.Root("read_cache"),
+			"Invalid Migadu API Read Cache",
+			"The supplied read cache value cannot be parsed into a boolean: "+err.Error(),
+		)
+	}
+
+	validateCredentialsValue, err := strconv.ParseBool(validateCredentials)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			path.Root("validate_credentials"),
+			"Invalid Migadu API Validate Credentials",
+			"The supplied validate credentials value cannot be parsed into a boolean: "+err.Error(),
+		)
+	}
+
+	adoptExistingValue, err := strconv.ParseBool(adoptExisting)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			path.Root("adopt_existing"),
+			"Invalid Migadu API Adopt Existing",
+			"The supplied adopt existing value cannot be parsed into a boolean: "+err.Error(),
+		)
+	}
+
+	var proxyURLValue *url.URL
+	if proxyURL != "" {
+		proxyURLValue, err = url.Parse(proxyURL)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Migadu API Proxy URL",
+				"The supplied proxy URL cannot be parsed: "+err.Error(),
+			)
+		} else if proxyURLValue.Scheme == "" || proxyURLValue.Host == "" {
+			response.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Migadu API Proxy URL",
+				"The supplied proxy URL must contain a scheme and a host, e.g. 'http://proxy.example.com:3128'.",
+			)
+		}
+	}
+
+	var rootCAs *x509.CertPool
+	if caBundleFile != "" || caBundlePEM != "" {
+		rootCAs = NewCertPool()
+	}
+
+	if caBundleFile != "" {
+		caBundle, err := os.ReadFile(caBundleFile)
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("ca_bundle_file"),
+				"Invalid Migadu API CA Bundle File",
+				"The supplied CA bundle file cannot be read: "+err.Error(),
+			)
+		} else if !rootCAs.AppendCertsFromPEM(caBundle) {
+			response.Diagnostics.AddAttributeError(
+				path.Root("ca_bundle_file"),
+				"Invalid Migadu API CA Bundle File",
+				"The supplied CA bundle file does not contain any PEM encoded certificates.",
+			)
+		}
+	}
+
+	if caBundlePEM != "" && !rootCAs.AppendCertsFromPEM([]byte(caBundlePEM)) {
+		response.Diagnostics.AddAttributeError(
+			path.Root("ca_bundle_pem"),
+			"Invalid Migadu API CA Bundle PEM",
+			"The supplied CA bundle does not contain any PEM encoded certificates.",
+		)
+	}
+
+	var certificates []tls.Certificate
+	if clientCert != "" && clientKey == "" {
+		response.Diagnostics.AddAttributeError(
+			path.Root("client_key"),
+			"Missing Migadu API Client Key",
+			"The provider cannot use the client certificate as there is a missing or empty value for its private key. "+
+				"Set the client_key value in the configuration or use the MIGADU_CLIENT_KEY environment variable.",
+		)
+	} else if clientCert == "" && clientKey != "" {
+		response.Diagnostics.AddAttributeError(
+			path.Root("client_cert"),
+			"Missing Migadu API Client Certificate",
+			"The provider cannot use the client key as there is a missing or empty value for its certificate. "+
+				"Set the client_cert value in the configuration or use the MIGADU_CLIENT_CERT environment variable.",
+		)
+	} else if clientCert != "" {
+		certificate, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			response.Diagnostics.AddAttributeError(
+				path.Root("client_cert"),
+				"Invalid Migadu API Client Certificate",
+				"The supplied client certificate and key cannot be parsed: "+err.Error(),
+			)
+		} else {
+			certificates = append(certificates, certificate)
+		}
+	}
+
+	insecureSkipVerifyValue, err := strconv.ParseBool(insecureSkipVerify)
+	if err != nil {
+		response.Diagnostics.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Invalid Migadu API Insecure Skip Verify",
+			"The supplied insecure skip verify value cannot be parsed into a boolean: "+err.Error(),
+		)
+	} else if insecureSkipVerifyValue {
+		response.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"Insecure Migadu API Connection",
+			"The provider does not verify the TLS certificate presented by the server. "+
+				"Anyone able to intercept the connection can read and modify all API requests including the API token. "+
+				"Trust the certificate authority of the server with ca_bundle_file or ca_bundle_pem instead.",
+		)
+	}
+
 	if response.Diagnostics.HasError() {
 		return
 	}
@@ -268,17 +806,24 @@ func (p *MigaduProvider) Configure(ctx context.Context, request provider.Configu
 	ctx = tflog.SetField(ctx, "migadu_timeout", timeout)
 	ctx = tflog.SetField(ctx, "migadu_rate_limit", rateLimit)
 	ctx = tflog.SetField(ctx, "migadu_rate_interval", rateInterval)
+	ctx = tflog.SetField(ctx, "migadu_max_concurrent_requests", maxConcurrentRequests)
+	ctx = tflog.SetField(ctx, "migadu_max_retries", maxRetries)
+	ctx = tflog.SetField(ctx, "migadu_retry_min_backoff", retryMinBackoff)
+	ctx = tflog.SetField(ctx, "migadu_retry_max_backoff", retryMaxBackoff)
+	ctx = tflog.SetField(ctx, "migadu_read_cache", readCache)
+	ctx = tflog.SetField(ctx, "migadu_validate_credentials", validateCredentials)
+	ctx = tflog.SetField(ctx, "migadu_adopt_existing", adoptExisting)
+	ctx = tflog.SetField(ctx, "migadu_ca_bundle_file", caBundleFile)
+	ctx = tflog.SetField(ctx, "migadu_insecure_skip_verify", insecureSkipVerify)
+	if proxyURLValue != nil {
+		ctx = tflog.SetField(ctx, "migadu_proxy_url", proxyURLValue.Redacted())
+	}
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "migadu_username")
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "migadu_token")
 
 	tflog.Debug(ctx, "Creating Migadu client")
 
-	var opts []client.Option
-	if rateLimitValue > 0 {
-		opts = append(opts, client.WithRateLimit(rateLimitValue, rateIntervalDuration))
-	}
-
-	c, err := client.New(&endpoint, &username, &token, duration, opts...)
+	c, err := client.New(&endpoint, &username, &token, duration)
 	if err != nil {
 		response.Diagnostics.AddError(
 			"Unable to Create Migadu API Client",
@@ -289,8 +834,43 @@ func (p *MigaduProvider) Configure(ctx context.Context, request provider.Configu
 		return
 	}
 
+	limiter := SharedAccountLimiter(ctx, endpoint, username, AccountLimitSettings{
+		RateLimit:             rateLimitValue,
+		RateInterval:          rateIntervalDuration,
+		MaxConcurrentRequests: maxConcurrentRequestsValue,
+	})
+	retrySettings := RetrySettings{
+		MaxRetries: maxRetriesValue,
+		MinBackoff: retryMinBackoffDuration,
+		MaxBackoff: retryMaxBackoffDuration,
+	}
+
+	httpTransport := NewHTTPTransport(HTTPTransportSettings{
+		ProxyURL:           proxyURLValue,
+		RootCAs:            rootCAs,
+		Certificates:       certificates,
+		InsecureSkipVerify: insecureSkipVerifyValue,
+	})
+	// The timeout applies to each attempt rather than to all retries of a request combined and does not include the
+	// time spent waiting for the rate and concurrency limits.
+	c.HTTPClient.Transport = NewMigaduTransport(httpTransport, retrySettings, limiter, c.HTTPClient.Timeout)
+	c.HTTPClient.Timeout = 0
+
+	if validateCredentialsValue {
+		tflog.Debug(ctx, "Validating Migadu credentials")
+		response.Diagnostics.Append(checkCredentials(ctx, c.HTTPClient, endpoint, username, token)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	response.DataSourceData = c
-	response.ResourceData = c
+	response.ResourceData = &MigaduProviderData{
+		Client:        c,
+		ReadCache:     NewReadCache(c, readCacheValue),
+		AdoptExisting: adoptExistingValue,
+	}
+	response.ListResourceData = c
 
 	tflog.Info(ctx, "Configured Migadu client")
 }
@@ -299,9 +879,14 @@ func (p *MigaduProvider) DataSources(_ context.Context) []func() datasource.Data
 	return []func() datasource.DataSource{
 		NewAliasDataSource,
 		NewAliasesDataSource,
+		NewDomainDataSource,
+		NewDomainDiagnosticsDataSource,
+		NewDomainDNSRecordsDataSource,
+		NewDomainsDataSource,
 		NewIdentitiesDataSource,
 		NewIdentityDataSource,
 		NewMailboxDataSource,
+		NewMailboxForwardingsDataSource,
 		NewMailboxesDataSource,
 		NewRewriteRuleDataSource,
 		NewRewriteRulesDataSource,
@@ -311,8 +896,42 @@ func (p *MigaduProvider) DataSources(_ context.Context) []func() datasource.Data
 func (p *MigaduProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAliasResource,
+		NewAliasDestinationResource,
+		NewDomainResource,
 		NewIdentityResource,
 		NewMailboxResource,
+		NewMailboxAutoresponderResource,
+		NewMailboxDelegationResource,
+		NewMailboxForwardingResource,
+		NewMailboxListEntryResource,
 		NewRewriteRuleResource,
+		NewRewriteRuleSetResource,
+	}
+}
+
+func (p *MigaduProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewPasswordEphemeralResource,
+	}
+}
+
+func (p *MigaduProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewAliasListResource,
+		NewIdentityListResource,
+		NewMailboxListResource,
+		NewRewriteRuleListResource,
+	}
+}
+
+func (p *MigaduProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewEmailsEqualFunction,
+		NewNormalizeEmailFunction,
+		NewParseEmailFunction,
+		NewRewriteRuleMatchesFunction,
+		NewRewriteRuleResolveFunction,
+		NewToASCIIDomainFunction,
+		NewToUnicodeDomainFunction,
 	}
 }
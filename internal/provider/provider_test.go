@@ -6,14 +6,24 @@
 package provider_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	internal "github.com/metio/terraform-provider-migadu/internal/provider"
 	"github.com/stretchr/testify/assert"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestMigaduProvider_Metadata(t *testing.T) {
@@ -62,3 +72,115 @@ type ResourceTestStep[T any] struct {
 	Send T
 	Want T
 }
+
+// upgradeState runs the state upgrader of the given resource for the given schema version against the given raw JSON state.
+func upgradeState(t *testing.T, r fwresource.Resource, version int64, rawState string) tfsdk.State {
+	ctx := context.Background()
+	schemaResponse := &fwresource.SchemaResponse{}
+	r.Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+
+	upgrader, ok := r.(fwresource.ResourceWithUpgradeState).UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("No state upgrader for version %d", version)
+	}
+
+	request := fwresource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(rawState)},
+	}
+	response := &fwresource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResponse.Schema},
+	}
+	upgrader.StateUpgrader(ctx, request, response)
+
+	if response.Diagnostics.HasError() {
+		t.Fatalf("UpgradeState diagnostics: %+v", response.Diagnostics)
+	}
+
+	return response.State
+}
+
+// latencyInjector delays all API responses to requests using the given HTTP method. Use it to simulate a slow API.
+type latencyInjector struct {
+	handler http.Handler
+	mutex   sync.Mutex
+	method  string
+	latency time.Duration
+}
+
+func newLatencyInjector(handler http.Handler) *latencyInjector {
+	return &latencyInjector{handler: handler}
+}
+
+// Inject delays all following requests using the given HTTP method. Use an empty method to remove the latency.
+func (l *latencyInjector) Inject(method string, latency time.Duration) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.method = method
+	l.latency = latency
+}
+
+func (l *latencyInjector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.mutex.Lock()
+	method, latency := l.method, l.latency
+	l.mutex.Unlock()
+
+	if r.Method == method {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-time.After(latency):
+		}
+	}
+
+	l.handler.ServeHTTP(w, r)
+}
+
+// conflictOnCreate answers all requests that create objects with a conflict, just like the Migadu API does for objects
+// that already exist. All other requests are passed to the given handler.
+func conflictOnCreate(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// bodyRecorder records the JSON fields sent by all requests using the given HTTP method. All requests are passed to
+// the given handler.
+type bodyRecorder struct {
+	handler http.Handler
+	method  string
+	mutex   sync.Mutex
+	fields  [][]string
+}
+
+func newBodyRecorder(handler http.Handler, method string) *bodyRecorder {
+	return &bodyRecorder{handler: handler, method: method}
+}
+
+// Fields returns the sorted JSON fields of each recorded request and forgets them.
+func (b *bodyRecorder) Fields() [][]string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	fields := b.fields
+	b.fields = nil
+	return fields
+}
+
+func (b *bodyRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == b.method {
+		body, _ := io.ReadAll(r.Body)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		var object map[string]any
+		_ = json.Unmarshal(body, &object)
+
+		b.mutex.Lock()
+		b.fields = append(b.fields, slices.Sorted(maps.Keys(object)))
+		b.mutex.Unlock()
+	}
+
+	b.handler.ServeHTTP(w, r)
+}
@@ -0,0 +1,170 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/migadu-client.go/model"
+	"strings"
+	"sync"
+)
+
+const (
+	readCacheKindAliases      = "aliases"
+	readCacheKindMailboxes    = "mailboxes"
+	readCacheKindRewriteRules = "rewrite_rules"
+)
+
+// ReadCache answers single-object reads of resources from the collection of their domain. The collection is fetched
+// once per domain on the first read, so that refreshing many objects of the same domain requires a single API request.
+// Successful mutations must call Invalidate for their domain. A disabled cache passes all reads through to the API.
+type ReadCache struct {
+	client  *client.MigaduClient
+	enabled bool
+	mutex   sync.Mutex
+	entries map[string]*readCacheEntry
+}
+
+type readCacheEntry struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+func NewReadCache(migaduClient *client.MigaduClient, enabled bool) *ReadCache {
+	return &ReadCache{
+		client:  migaduClient,
+		enabled: enabled,
+		entries: map[string]*readCacheEntry{},
+	}
+}
+
+func (c *ReadCache) GetAlias(ctx context.Context, domain string, localPart string) (*model.Alias, error) {
+	if c.enabled {
+		aliases, err := loadReadCacheEntry(ctx, c, readCacheKindAliases, domain, func(ctx context.Context) ([]model.Alias, error) {
+			aliases, err := c.client.GetAliases(ctx, domain)
+			if err != nil {
+				return nil, err
+			}
+			return aliases.Aliases, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, alias := range aliases {
+			if strings.EqualFold(alias.LocalPart, localPart) {
+				return &alias, nil
+			}
+		}
+	}
+	return c.client.GetAlias(ctx, domain, localPart)
+}
+
+func (c *ReadCache) GetMailbox(ctx context.Context, domain string, localPart string) (*model.Mailbox, error) {
+	if c.enabled {
+		mailboxes, err := loadReadCacheEntry(ctx, c, readCacheKindMailboxes, domain, func(ctx context.Context) ([]model.Mailbox, error) {
+			mailboxes, err := c.client.GetMailboxes(ctx, domain)
+			if err != nil {
+				return nil, err
+			}
+			return mailboxes.Mailboxes, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, mailbox := range mailboxes {
+			if strings.EqualFold(mailbox.LocalPart, localPart) {
+				return &mailbox, nil
+			}
+		}
+	}
+	return c.client.GetMailbox(ctx, domain, localPart)
+}
+
+func (c *ReadCache) GetRewriteRule(ctx context.Context, domain string, name string) (*model.RewriteRule, error) {
+	if c.enabled {
+		rules, err := loadReadCacheEntry(ctx, c, readCacheKindRewriteRules, domain, func(ctx context.Context) ([]model.RewriteRule, error) {
+			rules, err := c.client.GetRewriteRules(ctx, domain)
+			if err != nil {
+				return nil, err
+			}
+			return rules.RewriteRules, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			if rule.Name == name {
+				return &rule, nil
+			}
+		}
+	}
+	return c.client.GetRewriteRule(ctx, domain, name)
+}
+
+// Invalidate drops all cached collections of the given domain. Unicode and punycode domain names map to the same
+// collections.
+func (c *ReadCache) Invalidate(domain string) {
+	if !c.enabled {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, kind := range []string{readCacheKindAliases, readCacheKindMailboxes, readCacheKindRewriteRules} {
+		delete(c.entries, readCacheKey(kind, domain))
+	}
+}
+
+// loadReadCacheEntry returns the cached collection of the given kind and domain or fetches it. Concurrent reads of
+// the same collection wait for a single fetch. A failed fetch is not cached and is retried by the next read.
+func loadReadCacheEntry[T any](ctx context.Context, c *ReadCache, kind string, domain string, fetch func(context.Context) ([]T, error)) ([]T, error) {
+	key := readCacheKey(kind, domain)
+
+	for {
+		c.mutex.Lock()
+		entry, ok := c.entries[key]
+		if !ok {
+			entry = &readCacheEntry{done: make(chan struct{})}
+			c.entries[key] = entry
+			c.mutex.Unlock()
+
+			tflog.Debug(ctx, "Filling read cache", map[string]interface{}{
+				"kind":   kind,
+				"domain": domain,
+			})
+
+			values, err := fetch(ctx)
+			c.mutex.Lock()
+			entry.value, entry.err = values, err
+			if err != nil && c.entries[key] == entry {
+				delete(c.entries, key)
+			}
+			c.mutex.Unlock()
+			close(entry.done)
+
+			return values, err
+		}
+		c.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-entry.done:
+		}
+
+		if entry.err == nil {
+			return entry.value.([]T), nil
+		}
+		// the fetch of another read failed, e.g. because its timeout expired, so try again with the current context
+	}
+}
+
+func readCacheKey(kind, domain string) string {
+	return kind + ":" + canonicalDomainName(domain)
+}
@@ -0,0 +1,170 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"errors"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	internal "github.com/metio/terraform-provider-migadu/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReadCache(t *testing.T) {
+	testCases := map[string]struct {
+		enabled bool
+		read    func(ctx context.Context, cache *internal.ReadCache, name string) (string, error)
+		// wantRequests contains the expected number of API requests after reading all objects, after reading the
+		// first object again, after invalidating the domain and reading the first object again, and after reading a
+		// missing object.
+		wantRequests []int32
+	}{
+		"aliases-enabled": {
+			enabled:      true,
+			read:         readCacheAlias,
+			wantRequests: []int32{1, 1, 2, 3},
+		},
+		"aliases-disabled": {
+			enabled:      false,
+			read:         readCacheAlias,
+			wantRequests: []int32{3, 4, 5, 6},
+		},
+		"mailboxes-enabled": {
+			enabled:      true,
+			read:         readCacheMailbox,
+			wantRequests: []int32{1, 1, 2, 3},
+		},
+		"mailboxes-disabled": {
+			enabled:      false,
+			read:         readCacheMailbox,
+			wantRequests: []int32{3, 4, 5, 6},
+		},
+		"rewrite-rules-enabled": {
+			enabled:      true,
+			read:         readCacheRewriteRule,
+			wantRequests: []int32{1, 1, 2, 3},
+		},
+		"rewrite-rules-disabled": {
+			enabled:      false,
+			read:         readCacheRewriteRule,
+			wantRequests: []int32{3, 4, 5, 6},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			cache, requests := newReadCacheTestSetup(t, testCase.enabled)
+
+			for _, object := range []string{"first", "second", "third"} {
+				got, err := testCase.read(ctx, cache, object)
+				assert.NoError(t, err, "read %s", object)
+				assert.Equal(t, object, got, "read %s", object)
+			}
+			assert.Equal(t, testCase.wantRequests[0], requests.Load(), "requests after reading all objects")
+
+			_, err := testCase.read(ctx, cache, "first")
+			assert.NoError(t, err, "read first again")
+			assert.Equal(t, testCase.wantRequests[1], requests.Load(), "requests after reading first object again")
+
+			cache.Invalidate("EXAMPLE.com")
+			_, err = testCase.read(ctx, cache, "first")
+			assert.NoError(t, err, "read first after invalidation")
+			assert.Equal(t, testCase.wantRequests[2], requests.Load(), "requests after invalidation")
+
+			_, err = testCase.read(ctx, cache, "missing")
+			var requestError *client.RequestError
+			if assert.True(t, errors.As(err, &requestError), "read missing") {
+				assert.Equal(t, http.StatusNotFound, requestError.StatusCode, "StatusCode")
+			}
+			assert.Equal(t, testCase.wantRequests[3], requests.Load(), "requests after reading missing object")
+		})
+	}
+}
+
+func TestReadCache_Concurrent(t *testing.T) {
+	cache, requests := newReadCacheTestSetup(t, true)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			got, err := readCacheAlias(context.Background(), cache, "second")
+			assert.NoError(t, err, "read")
+			assert.Equal(t, "second", got, "read")
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), requests.Load(), "requests")
+}
+
+func newReadCacheTestSetup(t *testing.T, enabled bool) (*internal.ReadCache, *atomic.Int32) {
+	api := simulator.MigaduAPI(t, &simulator.State{
+		Aliases: []model.Alias{
+			{LocalPart: "first", DomainName: "example.com", Address: "first@example.com", Destinations: []string{"someone@example.com"}},
+			{LocalPart: "second", DomainName: "example.com", Address: "second@example.com", Destinations: []string{"someone@example.com"}},
+			{LocalPart: "third", DomainName: "example.com", Address: "third@example.com", Destinations: []string{"someone@example.com"}},
+		},
+		Mailboxes: []model.Mailbox{
+			{LocalPart: "first", DomainName: "example.com", Address: "first@example.com"},
+			{LocalPart: "second", DomainName: "example.com", Address: "second@example.com"},
+			{LocalPart: "third", DomainName: "example.com", Address: "third@example.com"},
+		},
+		Rewrites: []model.RewriteRule{
+			{DomainName: "example.com", Name: "first", LocalPartRule: "first-*", Destinations: []string{"someone@example.com"}},
+			{DomainName: "example.com", Name: "second", LocalPartRule: "second-*", Destinations: []string{"someone@example.com"}},
+			{DomainName: "example.com", Name: "third", LocalPartRule: "third-*", Destinations: []string{"someone@example.com"}},
+		},
+	})
+
+	requests := &atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		api.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	endpoint := server.URL
+	username := "username"
+	token := "token"
+	migaduClient, err := client.New(&endpoint, &username, &token, 10*time.Second)
+	if err != nil {
+		t.Fatalf("client.New: %v", err)
+	}
+
+	return internal.NewReadCache(migaduClient, enabled), requests
+}
+
+func readCacheAlias(ctx context.Context, cache *internal.ReadCache, name string) (string, error) {
+	alias, err := cache.GetAlias(ctx, "example.com", name)
+	if err != nil {
+		return "", err
+	}
+	return alias.LocalPart, nil
+}
+
+func readCacheMailbox(ctx context.Context, cache *internal.ReadCache, name string) (string, error) {
+	mailbox, err := cache.GetMailbox(ctx, "example.com", name)
+	if err != nil {
+		return "", err
+	}
+	return mailbox.LocalPart, nil
+}
+
+func readCacheRewriteRule(ctx context.Context, cache *internal.ReadCache, name string) (string, error) {
+	rule, err := cache.GetRewriteRule(ctx, "example.com", name)
+	if err != nil {
+		return "", err
+	}
+	return rule.Name, nil
+}
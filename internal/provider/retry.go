@@ -0,0 +1,175 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"errors"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetrySettings configures how often and how long API requests are retried.
+type RetrySettings struct {
+	// MaxRetries is the maximum number of retries after the first attempt. Set to 0 to disable retries.
+	MaxRetries int
+	// MinBackoff is the delay before the first retry. It doubles with each further retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential backoff. A longer Retry-After header sent by the API still takes precedence.
+	MaxBackoff time.Duration
+}
+
+// NewRetryTransport wraps the given transport and retries failed requests according to the given settings.
+//
+// Reads (GET, HEAD, OPTIONS) are retried on network errors, 429 and 5xx responses. Mutations are retried only when
+// the API did not process them: on 429 responses and on connection errors that happened before the request was sent.
+func NewRetryTransport(next http.RoundTripper, settings RetrySettings) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{
+		next:     next,
+		settings: settings,
+	}
+}
+
+type retryTransport struct {
+	next     http.RoundTripper
+	settings RetrySettings
+}
+
+func (t *retryTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx := request.Context()
+
+	for attempt := 0; ; attempt++ {
+		attemptRequest, err := t.attemptRequest(request, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := t.next.RoundTrip(attemptRequest)
+
+		if attempt >= t.settings.MaxRetries || !t.retryable(request, response, err) {
+			return response, err
+		}
+
+		delay := t.backoff(attempt, response)
+
+		fields := map[string]interface{}{
+			"method":      request.Method,
+			"path":        request.URL.Path,
+			"attempt":     attempt + 1,
+			"max_retries": t.settings.MaxRetries,
+			"delay":       delay.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["status_code"] = response.StatusCode
+			_, _ = io.Copy(io.Discard, response.Body)
+			_ = response.Body.Close()
+		}
+		tflog.Warn(ctx, "Retrying Migadu API request", fields)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// attemptRequest returns the request to send for the given attempt. Retries need a fresh copy of the request body.
+func (t *retryTransport) attemptRequest(request *http.Request, attempt int) (*http.Request, error) {
+	if attempt == 0 || request.Body == nil || request.Body == http.NoBody {
+		return request, nil
+	}
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	attemptRequest := request.Clone(request.Context())
+	attemptRequest.Body = body
+	return attemptRequest, nil
+}
+
+func (t *retryTransport) retryable(request *http.Request, response *http.Response, err error) bool {
+	if request.Context().Err() != nil {
+		return false
+	}
+	if request.Body != nil && request.Body != http.NoBody && request.GetBody == nil {
+		return false
+	}
+
+	if err != nil {
+		return isIdempotentRequest(request) || isConnectionError(err)
+	}
+
+	if response.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return isIdempotentRequest(request) && response.StatusCode >= http.StatusInternalServerError
+}
+
+// backoff returns the delay before the next retry. It grows exponentially with jitter and is replaced by the delay
+// requested by the API in its Retry-After header, if that is longer.
+func (t *retryTransport) backoff(attempt int, response *http.Response) time.Duration {
+	delay := t.settings.MinBackoff
+	for i := 0; i < attempt && delay < t.settings.MaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, t.settings.MaxBackoff)
+	if delay > 0 {
+		delay = delay/2 + rand.N(delay/2+1)
+	}
+
+	if response != nil {
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After")); ok && retryAfter > delay {
+			delay = retryAfter
+		}
+	}
+
+	return delay
+}
+
+func isIdempotentRequest(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// isConnectionError reports whether the request failed before it reached the API, e.g. because the connection was
+// refused or the host name could not be resolved.
+func isConnectionError(err error) bool {
+	var dnsError *net.DNSError
+	if errors.As(err, &dnsError) {
+		return true
+	}
+	var opError *net.OpError
+	return errors.As(err, &opError) && opError.Op == "dial"
+}
+
+// parseRetryAfter parses the value of a Retry-After header which is either a number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
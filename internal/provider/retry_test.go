@@ -0,0 +1,218 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	internal "github.com/metio/terraform-provider-migadu/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]struct {
+		method     string
+		maxRetries int
+		statuses   []int
+		retryAfter string
+		wantStatus int
+		wantCalls  int32
+	}{
+		"get-success": {
+			method:     http.MethodGet,
+			maxRetries: 3,
+			statuses:   []int{http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  1,
+		},
+		"get-bad-gateway": {
+			method:     http.MethodGet,
+			maxRetries: 3,
+			statuses:   []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  3,
+		},
+		"get-too-many-requests": {
+			method:     http.MethodGet,
+			maxRetries: 3,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		"get-not-found": {
+			method:     http.MethodGet,
+			maxRetries: 3,
+			statuses:   []int{http.StatusNotFound},
+			wantStatus: http.StatusNotFound,
+			wantCalls:  1,
+		},
+		"get-exhausted": {
+			method:     http.MethodGet,
+			maxRetries: 2,
+			statuses:   []int{http.StatusInternalServerError},
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  3,
+		},
+		"get-disabled": {
+			method:     http.MethodGet,
+			maxRetries: 0,
+			statuses:   []int{http.StatusInternalServerError, http.StatusOK},
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  1,
+		},
+		"post-internal-server-error": {
+			method:     http.MethodPost,
+			maxRetries: 3,
+			statuses:   []int{http.StatusInternalServerError, http.StatusOK},
+			wantStatus: http.StatusInternalServerError,
+			wantCalls:  1,
+		},
+		"post-too-many-requests": {
+			method:     http.MethodPost,
+			maxRetries: 3,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		"put-too-many-requests": {
+			method:     http.MethodPut,
+			maxRetries: 3,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+		"delete-bad-gateway": {
+			method:     http.MethodDelete,
+			maxRetries: 3,
+			statuses:   []int{http.StatusBadGateway, http.StatusOK},
+			wantStatus: http.StatusBadGateway,
+			wantCalls:  1,
+		},
+		"get-retry-after": {
+			method:     http.MethodGet,
+			maxRetries: 3,
+			statuses:   []int{http.StatusTooManyRequests, http.StatusOK},
+			retryAfter: "1",
+			wantStatus: http.StatusOK,
+			wantCalls:  2,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls atomic.Int32
+			var bodies []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := int(calls.Add(1))
+				body, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(body))
+				if testCase.retryAfter != "" {
+					w.Header().Set("Retry-After", testCase.retryAfter)
+				}
+				w.WriteHeader(testCase.statuses[min(call, len(testCase.statuses))-1])
+			}))
+			defer server.Close()
+
+			httpClient := &http.Client{
+				Transport: internal.NewRetryTransport(http.DefaultTransport, internal.RetrySettings{
+					MaxRetries: testCase.maxRetries,
+					MinBackoff: time.Millisecond,
+					MaxBackoff: 5 * time.Millisecond,
+				}),
+			}
+
+			request, err := http.NewRequest(testCase.method, server.URL, strings.NewReader(`{"name":"test"}`))
+			assert.NoError(t, err, "NewRequest")
+
+			start := time.Now()
+			response, err := httpClient.Do(request)
+			elapsed := time.Since(start)
+
+			assert.NoError(t, err, "Do")
+			assert.Equal(t, testCase.wantStatus, response.StatusCode, "StatusCode")
+			assert.Equal(t, testCase.wantCalls, calls.Load(), "calls")
+			for _, body := range bodies {
+				assert.Equal(t, `{"name":"test"}`, body, "body")
+			}
+			if testCase.retryAfter != "" {
+				assert.GreaterOrEqual(t, elapsed, time.Second, "Retry-After")
+			}
+		})
+	}
+}
+
+func TestRetryTransport_ConnectionRefused(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	endpoint := server.URL
+	server.Close()
+
+	var calls atomic.Int32
+	transport := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		calls.Add(1)
+		return http.DefaultTransport.RoundTrip(request)
+	})
+	httpClient := &http.Client{
+		Transport: internal.NewRetryTransport(transport, internal.RetrySettings{
+			MaxRetries: 2,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: time.Millisecond,
+		}),
+	}
+
+	request, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(`{}`))
+	assert.NoError(t, err, "NewRequest")
+
+	_, err = httpClient.Do(request)
+
+	assert.Error(t, err, "Do")
+	assert.Equal(t, int32(3), calls.Load(), "calls")
+}
+
+func TestRetryTransport_ContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{
+		Transport: internal.NewRetryTransport(http.DefaultTransport, internal.RetrySettings{
+			MaxRetries: 3,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: time.Millisecond,
+		}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	assert.NoError(t, err, "NewRequest")
+
+	_, err = httpClient.Do(request)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Do")
+	assert.Equal(t, int32(1), calls.Load(), "calls")
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
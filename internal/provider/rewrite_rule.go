@@ -6,12 +6,22 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+	"strings"
 )
 
+// rewriteRuleAPIAttributes maps API error fields to the attributes of the rewrite rule resource.
+var rewriteRuleAPIAttributes = map[string]string{
+	"name":            "name",
+	"local_part_rule": "local_part_rule",
+	"order_num":       "order_num",
+	"destinations":    "destinations",
+}
+
 func CreateRewriteRuleID(domainName custom_types.DomainNameValue, name types.String) string {
 	return CreateRewriteRuleIDString(domainName.ValueString(), name.ValueString())
 }
@@ -20,32 +30,50 @@ func CreateRewriteRuleIDString(domainName, name string) string {
 	return fmt.Sprintf("%s/%s", domainName, name)
 }
 
-func RewriteRuleCreateError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Creating RewriteRule Rule",
-		standardAPIErrorDetail(err),
-	)
+// rewriteRuleMatches checks whether the given local part matches the given local part rule. Rules use the wildcard
+// syntax of Migadu in which '*' matches any sequence of characters, including none. Matching ignores case.
+func rewriteRuleMatches(localPartRule, localPart string) bool {
+	patterns := strings.Split(strings.ToLower(strings.TrimSpace(localPartRule)), "*")
+	remaining := strings.ToLower(strings.TrimSpace(localPart))
+
+	if len(patterns) == 1 {
+		return remaining == patterns[0]
+	}
+
+	prefix, suffix := patterns[0], patterns[len(patterns)-1]
+	if !strings.HasPrefix(remaining, prefix) {
+		return false
+	}
+	remaining = remaining[len(prefix):]
+	if !strings.HasSuffix(remaining, suffix) {
+		return false
+	}
+	remaining = remaining[:len(remaining)-len(suffix)]
+
+	for _, pattern := range patterns[1 : len(patterns)-1] {
+		index := strings.Index(remaining, pattern)
+		if index < 0 {
+			return false
+		}
+		remaining = remaining[index+len(pattern):]
+	}
+	return true
 }
 
-func RewriteRuleReadError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Reading RewriteRule Rule",
-		standardAPIErrorDetail(err),
-	)
+func RewriteRuleCreateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Creating RewriteRule Rule", err, rewriteRuleAPIAttributes)
 }
 
-func RewriteRuleUpdateError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Updating RewriteRule Rule",
-		standardAPIErrorDetail(err),
-	)
+func RewriteRuleReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading RewriteRule Rule", err, rewriteRuleAPIAttributes)
 }
 
-func RewriteRuleDeleteError(err error) diag.Diagnostic {
-	return diag.NewErrorDiagnostic(
-		"Error Deleting RewriteRule Rule",
-		standardAPIErrorDetail(err),
-	)
+func RewriteRuleUpdateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Updating RewriteRule Rule", err, rewriteRuleAPIAttributes)
+}
+
+func RewriteRuleDeleteError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Deleting RewriteRule Rule", err, rewriteRuleAPIAttributes)
 }
 
 func RewriteRuleImportError(id string) diag.Diagnostic {
@@ -54,3 +82,10 @@ func RewriteRuleImportError(id string) diag.Diagnostic {
 		standardImportErrorDetail("domain_name/name", id),
 	)
 }
+
+func RewriteRuleImportIdentityError() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing RewriteRule Rule",
+		standardImportIdentityErrorDetail("domain_name", "name"),
+	)
+}
@@ -122,6 +122,8 @@ func (d *RewriteRuleDataSource) Configure(_ context.Context, request datasource.
 }
 
 func (d *RewriteRuleDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var data RewriteRuleDataSourceModel
 	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
 	if response.Diagnostics.HasError() {
@@ -130,7 +132,7 @@ func (d *RewriteRuleDataSource) Read(ctx context.Context, request datasource.Rea
 
 	rewrite, err := d.MigaduClient.GetRewriteRule(ctx, data.DomainName.ValueString(), data.Name.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(RewriteRuleReadError(err))
+		response.Diagnostics.Append(RewriteRuleReadError(ctx, err)...)
 		return
 	}
 
@@ -0,0 +1,249 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwlist "github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/querycheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestRewriteRuleListResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwlist.ListResourceSchemaRequest{}
+	schemaResponse := &fwlist.ListResourceSchemaResponse{}
+
+	provider.NewRewriteRuleListResource().ListResourceConfigSchema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestRewriteRuleListResource_API_Success(t *testing.T) {
+	testCases := map[string]struct {
+		config string
+		state  []model.RewriteRule
+		want   int
+	}{
+		"empty": {
+			config: `
+				domain_name = "example.com"
+			`,
+			want: 0,
+		},
+		"single": {
+			config: `
+				domain_name = "example.com"
+			`,
+			state: []model.RewriteRule{
+				{
+					DomainName:    "example.com",
+					Name:          "test",
+					LocalPartRule: "test-*",
+					OrderNum:      1,
+					Destinations:  []string{"someone@example.com"},
+				},
+			},
+			want: 1,
+		},
+		"multiple": {
+			config: `
+				domain_name = "example.com"
+			`,
+			state: []model.RewriteRule{
+				{
+					DomainName:    "example.com",
+					Name:          "test",
+					LocalPartRule: "test-*",
+					OrderNum:      1,
+					Destinations:  []string{"someone@example.com"},
+				},
+				{
+					DomainName:    "example.com",
+					Name:          "other",
+					LocalPartRule: "other-*",
+					OrderNum:      1,
+					Destinations:  []string{"someone@example.com"},
+				},
+			},
+			want: 2,
+		},
+		"other-domain": {
+			config: `
+				domain_name = "example.com"
+			`,
+			state: []model.RewriteRule{
+				{
+					DomainName:    "example.com",
+					Name:          "test",
+					LocalPartRule: "test-*",
+					OrderNum:      1,
+					Destinations:  []string{"someone@example.com"},
+				},
+				{
+					DomainName:    "example.org",
+					Name:          "test",
+					LocalPartRule: "test-*",
+					OrderNum:      1,
+					Destinations:  []string{"someone@example.org"},
+				},
+			},
+			want: 1,
+		},
+		"idna": {
+			config: `
+				domain_name = "hoß.de"
+			`,
+			state: []model.RewriteRule{
+				{
+					DomainName:    "xn--ho-hia.de",
+					Name:          "test",
+					LocalPartRule: "test-*",
+					OrderNum:      1,
+					Destinations:  []string{"someone@xn--ho-hia.de"},
+				},
+			},
+			want: 1,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Rewrites: testCase.state}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_14_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL),
+					},
+					{
+						Query: true,
+						Config: providerConfig(server.URL) + fmt.Sprintf(`
+							list "migadu_rewrite_rule" "test" {
+								provider = migadu
+
+								config {
+									%s
+								}
+							}
+						`, testCase.config),
+						QueryResultChecks: []querycheck.QueryResultCheck{
+							querycheck.ExpectLength("migadu_rewrite_rule.test", testCase.want),
+						},
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestRewriteRuleListResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Rewrites: []model.RewriteRule{
+		{
+			DomainName:    "example.com",
+			Name:          "test",
+			LocalPartRule: "test-*",
+			OrderNum:      1,
+			Destinations:  []string{"someone@example.com"},
+		},
+	}}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_14_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL),
+			},
+			{
+				Query: true,
+				Config: providerConfig(server.URL) + `
+					list "migadu_rewrite_rule" "test" {
+						provider = migadu
+
+						config {
+							domain_name = "example.com"
+						}
+					}
+				`,
+				QueryResultChecks: []querycheck.QueryResultCheck{
+					querycheck.ExpectIdentity("migadu_rewrite_rule.test", map[string]knownvalue.Check{
+						"domain_name": knownvalue.StringExact("example.com"),
+						"name":        knownvalue.StringExact("test"),
+					}),
+				},
+			},
+		},
+	})
+}
+
+func TestRewriteRuleListResource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetRewriteRules: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetRewriteRules: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_14_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL),
+					},
+					{
+						Query: true,
+						Config: providerConfig(server.URL) + `
+							list "migadu_rewrite_rule" "test" {
+								provider = migadu
+
+								config {
+									domain_name = "example.com"
+								}
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = (*RewriteRuleMatchesFunction)(nil)
+)
+
+func NewRewriteRuleMatchesFunction() function.Function {
+	return &RewriteRuleMatchesFunction{}
+}
+
+type RewriteRuleMatchesFunction struct{}
+
+func (f *RewriteRuleMatchesFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "rewrite_rule_matches"
+}
+
+func (f *RewriteRuleMatchesFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Checks whether a local part matches a rewrite rule.",
+		Description:         "Checks whether a local part matches the 'local_part_rule' of a rewrite rule. The wildcard '*' matches any sequence of characters, including none, e.g. 'sec-*' matches 'sec-' and 'sec-team', but not 'security'. Matching ignores case.",
+		MarkdownDescription: "Checks whether a local part matches the `local_part_rule` of a rewrite rule. The wildcard `*` matches any sequence of characters, including none, e.g. `sec-*` matches `sec-` and `sec-team`, but not `security`. Matching ignores case.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "rule",
+				Description:         "The local part rule of the rewrite rule.",
+				MarkdownDescription: "The local part rule of the rewrite rule.",
+			},
+			function.StringParameter{
+				Name:                "local_part",
+				Description:         "The local part of an email address.",
+				MarkdownDescription: "The local part of an email address.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *RewriteRuleMatchesFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var rule, localPart string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &rule, &localPart))
+	if response.Error != nil {
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, rewriteRuleMatches(rule, localPart)))
+}
@@ -0,0 +1,130 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwfunction "github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"testing"
+)
+
+func TestRewriteRuleMatchesFunction_Definition(t *testing.T) {
+	ctx := context.Background()
+	definitionRequest := fwfunction.DefinitionRequest{}
+	definitionResponse := &fwfunction.DefinitionResponse{}
+
+	provider.NewRewriteRuleMatchesFunction().Definition(ctx, definitionRequest, definitionResponse)
+
+	if definitionResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition method diagnostics: %+v", definitionResponse.Diagnostics)
+	}
+
+	validateResponse := &fwfunction.DefinitionValidateResponse{}
+	definitionResponse.Definition.ValidateImplementation(ctx, fwfunction.DefinitionValidateRequest{FuncName: "rewrite_rule_matches"}, validateResponse)
+	if validateResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition validation diagnostics: %+v", validateResponse.Diagnostics)
+	}
+}
+
+func TestRewriteRuleMatchesFunction_Success(t *testing.T) {
+	testCases := map[string]struct {
+		rule      string
+		localPart string
+		want      string
+	}{
+		"exact": {
+			rule:      "info",
+			localPart: "info",
+			want:      "true",
+		},
+		"exact-mismatch": {
+			rule:      "info",
+			localPart: "info2",
+			want:      "false",
+		},
+		"prefix": {
+			rule:      "sec-*",
+			localPart: "sec-team",
+			want:      "true",
+		},
+		"prefix-empty": {
+			rule:      "sec-*",
+			localPart: "sec-",
+			want:      "true",
+		},
+		"prefix-mismatch": {
+			rule:      "sec-*",
+			localPart: "security",
+			want:      "false",
+		},
+		"suffix": {
+			rule:      "*-sales",
+			localPart: "eu-sales",
+			want:      "true",
+		},
+		"infix": {
+			rule:      "team-*-lead",
+			localPart: "team-ops-lead",
+			want:      "true",
+		},
+		"infix-mismatch": {
+			rule:      "team-*-lead",
+			localPart: "team-ops",
+			want:      "false",
+		},
+		"multiple-wildcards": {
+			rule:      "a*b*c",
+			localPart: "axxbyyc",
+			want:      "true",
+		},
+		"multiple-wildcards-order": {
+			rule:      "a*b*c",
+			localPart: "acb",
+			want:      "false",
+		},
+		"overlapping": {
+			rule:      "a*a",
+			localPart: "a",
+			want:      "false",
+		},
+		"everything": {
+			rule:      "*",
+			localPart: "anything",
+			want:      "true",
+		},
+		"uppercase": {
+			rule:      "SEC-*",
+			localPart: "Sec-Team",
+			want:      "true",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::migadu::rewrite_rule_matches("%s", "%s")
+							}
+						`, testCase.rule, testCase.localPart),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckOutput("test", testCase.want),
+						),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = (*RewriteRuleResolveFunction)(nil)
+)
+
+func NewRewriteRuleResolveFunction() function.Function {
+	return &RewriteRuleResolveFunction{}
+}
+
+type RewriteRuleResolveFunction struct{}
+
+type RewriteRuleResolveFunctionRule struct {
+	LocalPartRule types.String `tfsdk:"local_part_rule"`
+	Destinations  []string     `tfsdk:"destinations"`
+}
+
+func (f *RewriteRuleResolveFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "rewrite_rule_resolve"
+}
+
+func (f *RewriteRuleResolveFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Resolves the destinations of an email address using rewrite rules.",
+		Description:         "Returns the destinations of the first rewrite rule whose 'local_part_rule' matches the local part of the given email address, or an empty list in case no rule matches. Rules are matched like 'rewrite_rule_matches' does. The domain of the email address is ignored.",
+		MarkdownDescription: "Returns the destinations of the first rewrite rule whose `local_part_rule` matches the local part of the given email address, or an empty list in case no rule matches. Rules are matched like `rewrite_rule_matches` does. The domain of the email address is ignored.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "rules",
+				Description:         "The rewrite rules ordered by their order number, e.g. the 'rules' attribute of a 'migadu_rewrite_rule_set' resource. Each rule must have the attributes 'local_part_rule' and 'destinations', other attributes are ignored.",
+				MarkdownDescription: "The rewrite rules ordered by their order number, e.g. the `rules` attribute of a `migadu_rewrite_rule_set` resource. Each rule must have the attributes `local_part_rule` and `destinations`, other attributes are ignored.",
+				ElementType: types.ObjectType{
+					AttrTypes: map[string]attr.Type{
+						"local_part_rule": types.StringType,
+						"destinations":    types.ListType{ElemType: types.StringType},
+					},
+				},
+			},
+			function.StringParameter{
+				Name:                "address",
+				Description:         "The email address to resolve.",
+				MarkdownDescription: "The email address to resolve.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *RewriteRuleResolveFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var rules []RewriteRuleResolveFunctionRule
+	var address string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &rules, &address))
+	if response.Error != nil {
+		return
+	}
+
+	localPart, _, funcErr := splitEmailArgument(1, address)
+	if funcErr != nil {
+		response.Error = funcErr
+		return
+	}
+
+	destinations := []string{}
+	for _, rule := range rules {
+		if rewriteRuleMatches(rule.LocalPartRule.ValueString(), localPart) {
+			destinations = append(destinations, rule.Destinations...)
+			break
+		}
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, destinations))
+}
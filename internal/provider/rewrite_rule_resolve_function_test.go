@@ -0,0 +1,181 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwfunction "github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestRewriteRuleResolveFunction_Definition(t *testing.T) {
+	ctx := context.Background()
+	definitionRequest := fwfunction.DefinitionRequest{}
+	definitionResponse := &fwfunction.DefinitionResponse{}
+
+	provider.NewRewriteRuleResolveFunction().Definition(ctx, definitionRequest, definitionResponse)
+
+	if definitionResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition method diagnostics: %+v", definitionResponse.Diagnostics)
+	}
+
+	validateResponse := &fwfunction.DefinitionValidateResponse{}
+	definitionResponse.Definition.ValidateImplementation(ctx, fwfunction.DefinitionValidateRequest{FuncName: "rewrite_rule_resolve"}, validateResponse)
+	if validateResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition validation diagnostics: %+v", validateResponse.Diagnostics)
+	}
+}
+
+func TestRewriteRuleResolveFunction_Success(t *testing.T) {
+	testCases := map[string]struct {
+		address string
+		want    string
+	}{
+		"first-rule": {
+			address: "sec-team@example.com",
+			want:    "security@example.com,ops@example.com",
+		},
+		"second-rule": {
+			address: "sales@example.com",
+			want:    "sales-team@example.com",
+		},
+		"first-match-wins": {
+			address: "sec-sales@example.com",
+			want:    "security@example.com,ops@example.com",
+		},
+		"no-match": {
+			address: "info@example.com",
+			want:    "",
+		},
+		"uppercase": {
+			address: "SEC-Team@example.com",
+			want:    "security@example.com,ops@example.com",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							locals {
+								rules = [
+									{
+										local_part_rule = "sec-*"
+										destinations    = ["security@example.com", "ops@example.com"]
+									},
+									{
+										local_part_rule = "*sales"
+										destinations    = ["sales-team@example.com"]
+									},
+								]
+							}
+							output "test" {
+								value = join(",", provider::migadu::rewrite_rule_resolve(local.rules, "%s"))
+							}
+						`, testCase.address),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckOutput("test", testCase.want),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestRewriteRuleResolveFunction_RewriteRuleSet(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_8_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_rewrite_rule_set" "test" {
+						domain_name = "example.com"
+						rules       = [
+							{
+								name            = "first"
+								local_part_rule = "first-*"
+								destinations    = ["first@example.com"]
+							},
+							{
+								name            = "second"
+								local_part_rule = "*"
+								destinations    = ["second@example.com"]
+							},
+						]
+					}
+					output "first" {
+						value = join(",", provider::migadu::rewrite_rule_resolve(migadu_rewrite_rule_set.test.rules, "first-one@example.com"))
+					}
+					output "second" {
+						value = join(",", provider::migadu::rewrite_rule_resolve(migadu_rewrite_rule_set.test.rules, "other@example.com"))
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckOutput("first", "first@example.com"),
+					resource.TestCheckOutput("second", "second@example.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestRewriteRuleResolveFunction_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		rules   string
+		address string
+		want    string
+	}{
+		"invalid-address": {
+			rules:   `[{ local_part_rule = "*", destinations = ["someone@example.com"] }]`,
+			address: "someone",
+			want:    `An email must match the format 'local_part@domain'`,
+		},
+		"missing-destinations": {
+			rules:   `[{ local_part_rule = "*" }]`,
+			address: "someone@example.com",
+			want:    `attribute "destinations" is required`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::migadu::rewrite_rule_resolve(%s, "%s")
+							}
+						`, testCase.rules, testCase.address),
+						ExpectError: regexp.MustCompile(testCase.want),
+					},
+				},
+			})
+		})
+	}
+}
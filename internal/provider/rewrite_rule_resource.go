@@ -9,11 +9,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -29,9 +32,12 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*RewriteRuleResource)(nil)
-	_ resource.ResourceWithConfigure   = (*RewriteRuleResource)(nil)
-	_ resource.ResourceWithImportState = (*RewriteRuleResource)(nil)
+	_ resource.Resource                 = (*RewriteRuleResource)(nil)
+	_ resource.ResourceWithConfigure    = (*RewriteRuleResource)(nil)
+	_ resource.ResourceWithImportState  = (*RewriteRuleResource)(nil)
+	_ resource.ResourceWithIdentity     = (*RewriteRuleResource)(nil)
+	_ resource.ResourceWithUpgradeState = (*RewriteRuleResource)(nil)
+	_ resource.ResourceWithMoveState    = (*RewriteRuleResource)(nil)
 )
 
 func NewRewriteRuleResource() resource.Resource {
@@ -39,7 +45,9 @@ func NewRewriteRuleResource() resource.Resource {
 }
 
 type RewriteRuleResource struct {
-	MigaduClient *client.MigaduClient
+	MigaduClient  *client.MigaduClient
+	ReadCache     *ReadCache
+	AdoptExisting bool
 }
 
 type RewriteRuleResourceModel struct {
@@ -49,16 +57,24 @@ type RewriteRuleResourceModel struct {
 	LocalPartRule types.String                      `tfsdk:"local_part_rule"`
 	OrderNum      types.Int64                       `tfsdk:"order_num"`
 	Destinations  custom_types.EmailAddressSetValue `tfsdk:"destinations"`
+	AdoptExisting types.Bool                        `tfsdk:"adopt_existing"`
+	Timeouts      timeouts.Value                    `tfsdk:"timeouts"`
+}
+
+type RewriteRuleResourceIdentityModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+	Name       types.String `tfsdk:"name"`
 }
 
 func (r *RewriteRuleResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
 	response.TypeName = request.ProviderTypeName + "_rewrite_rule"
 }
 
-func (r *RewriteRuleResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+func (r *RewriteRuleResource) Schema(ctx context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
 	response.Schema = schema.Schema{
 		Description:         "Provides a rewrite rule.",
 		MarkdownDescription: "Provides a rewrite rule.",
+		Version:             1,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Description:         "Contains the value 'domain_name/name'.",
@@ -71,8 +87,8 @@ func (r *RewriteRuleResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 			"local_part_rule": schema.StringAttribute{
-				Description:         "The regular expression matching the local part of incoming emails.",
-				MarkdownDescription: "The regular expression matching the local part of incoming emails.",
+				Description:         "The rule matching the local part of incoming emails. The wildcard '*' matches any sequence of characters, including none, e.g. 'sec-*' matches 'sec-' and 'sec-team'.",
+				MarkdownDescription: "The rule matching the local part of incoming emails. The wildcard `*` matches any sequence of characters, including none, e.g. `sec-*` matches `sec-` and `sec-team`.",
 				Required:            true,
 				Optional:            false,
 				Computed:            false,
@@ -133,31 +149,120 @@ func (r *RewriteRuleResource) Schema(_ context.Context, _ resource.SchemaRequest
 					setvalidator.SizeAtLeast(1),
 				},
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description:         "Whether to adopt an existing rewrite rule with the same identifier instead of failing to create it, e.g. after a previous apply timed out after the rewrite rule was created. The adopted rewrite rule is updated to match this resource. Defaults to the 'adopt_existing' attribute of the provider.",
+				MarkdownDescription: "Whether to adopt an existing rewrite rule with the same identifier instead of failing to create it, e.g. after a previous apply timed out after the rewrite rule was created. The adopted rewrite rule is updated to match this resource. Defaults to the `adopt_existing` attribute of the provider.",
+				Required:            false,
+				Optional:            true,
+				Computed:            false,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.BlockAll(ctx),
+		},
+	}
+}
+
+func (r *RewriteRuleResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the rewrite rule.",
+				RequiredForImport: true,
+			},
+			"name": identityschema.StringAttribute{
+				Description:       "The name (slug) of the rewrite rule.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *RewriteRuleResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: upgradeStateFromRawState,
+		},
+	}
+}
+
+func (r *RewriteRuleResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: r.moveStateFromRewrite,
 		},
 	}
 }
+
+// moveStateFromRewrite moves the state of the 'migadu_rewrite' resource which was renamed to 'migadu_rewrite_rule' in
+// release 2023.8.23. Other source resources are ignored so that the framework can report them as unsupported.
+func (r *RewriteRuleResource) moveStateFromRewrite(ctx context.Context, request resource.MoveStateRequest, response *resource.MoveStateResponse) {
+	if request.SourceTypeName != "migadu_rewrite" || !strings.HasSuffix(request.SourceProviderAddress, "metio/migadu") {
+		return
+	}
+
+	state, err := request.SourceRawState.UnmarshalWithOpts(response.TargetState.Schema.Type().TerraformType(ctx), rawStateUnmarshalOpts)
+	if err != nil {
+		response.Diagnostics.Append(StateMoveError(request.SourceTypeName, err))
+		return
+	}
+	response.TargetState.Raw = state
+
+	var target RewriteRuleResourceModel
+	response.Diagnostics.Append(response.TargetState.Get(ctx, &target)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	target.ID = types.StringValue(CreateRewriteRuleID(target.DomainName, target.Name))
+
+	tflog.Trace(ctx, "moved rewrite rule state", map[string]interface{}{
+		"source_type_name": request.SourceTypeName,
+		"domain_name":      target.DomainName.ValueString(),
+		"name":             target.Name.ValueString(),
+	})
+
+	response.Diagnostics.Append(response.TargetState.Set(ctx, &target)...)
+	response.Diagnostics.Append(response.TargetIdentity.Set(ctx, newRewriteRuleResourceIdentityModel(&target))...)
+}
+
 func (r *RewriteRuleResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
 	if request.ProviderData == nil {
 		return
 	}
 
-	if migaduClient, ok := request.ProviderData.(*client.MigaduClient); ok {
-		r.MigaduClient = migaduClient
+	if providerData, ok := request.ProviderData.(*MigaduProviderData); ok {
+		r.MigaduClient = providerData.Client
+		r.ReadCache = providerData.ReadCache
+		r.AdoptExisting = providerData.AdoptExisting
 	} else {
 		response.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.MigaduClient, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+			fmt.Sprintf("Expected *provider.MigaduProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
 		)
 	}
 }
 
 func (r *RewriteRuleResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var plan RewriteRuleResourceModel
 	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	var destinations []string
 	if !plan.Destinations.IsUnknown() {
 		response.Diagnostics.Append(plan.Destinations.ElementsAs(ctx, &destinations, false)...)
@@ -174,8 +279,14 @@ func (r *RewriteRuleResource) Create(ctx context.Context, request resource.Creat
 	}
 
 	createdRewrite, err := r.MigaduClient.CreateRewriteRule(ctx, plan.DomainName.ValueString(), rewrite)
-	if err != nil {
-		response.Diagnostics.Append(RewriteRuleCreateError(err))
+	if err != nil && isConflictError(err) && adoptExisting(plan.AdoptExisting, r.AdoptExisting) {
+		createdRewrite, diags = r.adopt(ctx, &plan, rewrite, err)
+		response.Diagnostics.Append(diags...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	} else if err != nil {
+		response.Diagnostics.Append(RewriteRuleCreateError(ctx, err)...)
 		return
 	}
 
@@ -185,16 +296,53 @@ func (r *RewriteRuleResource) Create(ctx context.Context, request resource.Creat
 	plan.OrderNum = types.Int64Value(createdRewrite.OrderNum)
 
 	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newRewriteRuleResourceIdentityModel(&plan))...)
+}
+
+// adopt updates the existing rewrite rule of the given plan to match the given rewrite rule. The given error of the failed create
+// request is reported in case no rewrite rule with the same name exists.
+func (r *RewriteRuleResource) adopt(ctx context.Context, plan *RewriteRuleResourceModel, rewrite *model.RewriteRule, createErr error) (*model.RewriteRule, diag.Diagnostics) {
+	createDiagnostics := RewriteRuleCreateError(ctx, createErr)
+
+	_, err := r.MigaduClient.GetRewriteRule(ctx, plan.DomainName.ValueString(), plan.Name.ValueString())
+	if isNotFoundError(err) {
+		return nil, createDiagnostics
+	}
+	if err != nil {
+		return nil, RewriteRuleReadError(ctx, err)
+	}
+
+	tflog.Info(ctx, "Adopting existing rewrite rule", map[string]interface{}{
+		"name":        plan.Name.ValueString(),
+		"domain_name": plan.DomainName.ValueString(),
+	})
+
+	updatedRewrite, err := r.MigaduClient.UpdateRewriteRule(ctx, plan.DomainName.ValueString(), plan.Name.ValueString(), rewrite)
+	if err != nil {
+		return nil, RewriteRuleUpdateError(ctx, err)
+	}
+	return updatedRewrite, nil
 }
 
 func (r *RewriteRuleResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var state RewriteRuleResourceModel
 	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	rewrite, err := r.MigaduClient.GetRewriteRule(ctx, state.DomainName.ValueString(), state.Name.ValueString())
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	rewrite, err := r.ReadCache.GetRewriteRule(ctx, state.DomainName.ValueString(), state.Name.ValueString())
 	if err != nil {
 		var requestError *client.RequestError
 		if errors.As(err, &requestError) {
@@ -203,16 +351,30 @@ func (r *RewriteRuleResource) Read(ctx context.Context, request resource.ReadReq
 				return
 			}
 		}
-		response.Diagnostics.Append(RewriteRuleReadError(err))
+		response.Diagnostics.Append(RewriteRuleReadError(ctx, err)...)
 		return
 	}
 
-	receivedDestinations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, rewrite.Destinations)
-	response.Diagnostics.Append(diags...)
+	response.Diagnostics.Append(refreshRewriteRuleResourceModel(ctx, &state, rewrite)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newRewriteRuleResourceIdentityModel(&state))...)
+}
+
+// refreshRewriteRuleResourceModel updates the given state with the values of the given rewrite rule. Values that are semantically
+// equal to the current state are kept.
+func refreshRewriteRuleResourceModel(ctx context.Context, state *RewriteRuleResourceModel, rewrite *model.RewriteRule) diag.Diagnostics {
+	var diagnostics diag.Diagnostics
+
+	receivedDestinations, diags := custom_types.NewEmailAddressSetValueFrom(ctx, rewrite.Destinations)
+	diagnostics.Append(diags...)
+	if diagnostics.HasError() {
+		return diagnostics
+	}
+
 	if equal, _ := state.Destinations.SetSemanticEquals(ctx, receivedDestinations); !equal {
 		state.Destinations = receivedDestinations
 	}
@@ -222,16 +384,36 @@ func (r *RewriteRuleResource) Read(ctx context.Context, request resource.ReadReq
 	state.LocalPartRule = types.StringValue(rewrite.LocalPartRule)
 	state.OrderNum = types.Int64Value(rewrite.OrderNum)
 
-	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	return diagnostics
+}
+
+func newRewriteRuleResourceIdentityModel(state *RewriteRuleResourceModel) RewriteRuleResourceIdentityModel {
+	return RewriteRuleResourceIdentityModel{
+		DomainName: types.StringValue(state.DomainName.ValueString()),
+		Name:       types.StringValue(state.Name.ValueString()),
+	}
 }
 
 func (r *RewriteRuleResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var plan RewriteRuleResourceModel
 	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	var destinations []string
 	response.Diagnostics.Append(plan.Destinations.ElementsAs(ctx, &destinations, false)...)
 	if response.Diagnostics.HasError() {
@@ -247,7 +429,7 @@ func (r *RewriteRuleResource) Update(ctx context.Context, request resource.Updat
 
 	updatedRewrite, err := r.MigaduClient.UpdateRewriteRule(ctx, plan.DomainName.ValueString(), plan.Name.ValueString(), rewrite)
 	if err != nil {
-		response.Diagnostics.Append(RewriteRuleUpdateError(err))
+		response.Diagnostics.Append(RewriteRuleUpdateError(ctx, err)...)
 		return
 	}
 
@@ -260,20 +442,59 @@ func (r *RewriteRuleResource) Update(ctx context.Context, request resource.Updat
 }
 
 func (r *RewriteRuleResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var state RewriteRuleResourceModel
 	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
 	if response.Diagnostics.HasError() {
 		return
 	}
 
+	defer r.ReadCache.Invalidate(state.DomainName.ValueString())
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultResourceTimeout)
+	response.Diagnostics.Append(diags...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	_, err := r.MigaduClient.DeleteRewriteRule(ctx, state.DomainName.ValueString(), state.Name.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(RewriteRuleDeleteError(err))
+		response.Diagnostics.Append(RewriteRuleDeleteError(ctx, err)...)
 		return
 	}
 }
 
 func (r *RewriteRuleResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID == "" && request.Identity != nil && !request.Identity.Raw.IsNull() {
+		var resourceIdentity RewriteRuleResourceIdentityModel
+		response.Diagnostics.Append(request.Identity.Get(ctx, &resourceIdentity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		domainName := resourceIdentity.DomainName.ValueString()
+		name := resourceIdentity.Name.ValueString()
+
+		if domainName == "" || name == "" {
+			response.Diagnostics.Append(RewriteRuleImportIdentityError())
+			return
+		}
+
+		tflog.Trace(ctx, "read import identity", map[string]interface{}{
+			"domain_name": domainName,
+			"name":        name,
+		})
+
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), CreateRewriteRuleIDString(domainName, name))...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("name"), name)...)
+		return
+	}
+
 	idParts := strings.Split(request.ID, "/")
 
 	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
@@ -9,15 +9,23 @@ import (
 	"context"
 	"fmt"
 	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 	"github.com/metio/migadu-client.go/model"
 	"github.com/metio/migadu-client.go/simulator"
 	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestRewriteRuleResource_Schema(t *testing.T) {
@@ -358,6 +366,262 @@ func TestRewriteRuleResource_API_Success(t *testing.T) {
 	}
 }
 
+func TestRewriteRuleResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_rewrite_rule" "test" {
+						domain_name     = "example.com"
+						name            = "sales"
+						local_part_rule = "sales-*"
+						destinations    = ["sales@example.com"]
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("migadu_rewrite_rule.test", map[string]knownvalue.Check{
+						"domain_name": knownvalue.StringExact("example.com"),
+						"name":        knownvalue.StringExact("sales"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "migadu_rewrite_rule.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestRewriteRuleResource_MoveState(t *testing.T) {
+	testCases := map[string]struct {
+		sourceTypeName        string
+		sourceProviderAddress string
+		moved                 bool
+	}{
+		"migadu-rewrite": {
+			sourceTypeName:        "migadu_rewrite",
+			sourceProviderAddress: "registry.terraform.io/metio/migadu",
+			moved:                 true,
+		},
+		"other-resource": {
+			sourceTypeName:        "migadu_alias",
+			sourceProviderAddress: "registry.terraform.io/metio/migadu",
+			moved:                 false,
+		},
+		"other-provider": {
+			sourceTypeName:        "migadu_rewrite",
+			sourceProviderAddress: "registry.terraform.io/someone/migadu",
+			moved:                 false,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			rewriteRule := provider.NewRewriteRuleResource()
+
+			schemaResponse := &fwresource.SchemaResponse{}
+			rewriteRule.Schema(ctx, fwresource.SchemaRequest{}, schemaResponse)
+			identitySchemaResponse := &fwresource.IdentitySchemaResponse{}
+			rewriteRule.(fwresource.ResourceWithIdentity).IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identitySchemaResponse)
+
+			request := fwresource.MoveStateRequest{
+				SourceTypeName:        testCase.sourceTypeName,
+				SourceProviderAddress: testCase.sourceProviderAddress,
+				SourceRawState: &tfprotov6.RawState{JSON: []byte(`{
+					"id": "sales",
+					"domain_name": "hoß.de",
+					"name": "sales",
+					"local_part_rule": "sales-*",
+					"order_num": 1,
+					"destinations": ["sales@hoß.de"],
+					"destinations_punycode": ["sales@xn--ho-hia.de"]
+				}`)},
+			}
+			response := &fwresource.MoveStateResponse{
+				TargetState: tfsdk.State{
+					Schema: schemaResponse.Schema,
+					Raw:    tftypes.NewValue(schemaResponse.Schema.Type().TerraformType(ctx), nil),
+				},
+				TargetIdentity: &tfsdk.ResourceIdentity{
+					Schema: identitySchemaResponse.IdentitySchema,
+					Raw:    tftypes.NewValue(identitySchemaResponse.IdentitySchema.Type().TerraformType(ctx), nil),
+				},
+			}
+
+			for _, mover := range rewriteRule.(fwresource.ResourceWithMoveState).MoveState(ctx) {
+				mover.StateMover(ctx, request, response)
+			}
+
+			if response.Diagnostics.HasError() {
+				t.Fatalf("MoveState diagnostics: %+v", response.Diagnostics)
+			}
+			if !testCase.moved {
+				assert.True(t, response.TargetState.Raw.IsNull(), "target state")
+				return
+			}
+
+			var moved provider.RewriteRuleResourceModel
+			response.Diagnostics.Append(response.TargetState.Get(ctx, &moved)...)
+			var identity provider.RewriteRuleResourceIdentityModel
+			response.Diagnostics.Append(response.TargetIdentity.Get(ctx, &identity)...)
+			if response.Diagnostics.HasError() {
+				t.Fatalf("State diagnostics: %+v", response.Diagnostics)
+			}
+
+			var destinations []string
+			moved.Destinations.ElementsAs(ctx, &destinations, false)
+
+			assert.Equal(t, "hoß.de/sales", moved.ID.ValueString(), "id")
+			assert.Equal(t, "sales-*", moved.LocalPartRule.ValueString(), "local_part_rule")
+			assert.Equal(t, int64(1), moved.OrderNum.ValueInt64(), "order_num")
+			assert.ElementsMatch(t, []string{"sales@hoß.de"}, destinations, "destinations")
+			assert.Equal(t, "hoß.de", identity.DomainName.ValueString(), "identity domain_name")
+			assert.Equal(t, "sales", identity.Name.ValueString(), "identity name")
+		})
+	}
+}
+
+func TestRewriteRuleResource_API_Timeouts(t *testing.T) {
+	api := newLatencyInjector(simulator.MigaduAPI(t, &simulator.State{}))
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	config := func(value string) string {
+		return providerConfig(server.URL) + fmt.Sprintf(`
+			resource "migadu_rewrite_rule" "test" {
+				domain_name     = "example.com"
+				name            = "sales"
+				local_part_rule = "sales-*"
+				destinations    = ["%s"]
+				timeouts {
+					create = "1s"
+					read   = "1s"
+					update = "1s"
+					delete = "1s"
+				}
+			}
+		`, value)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig:   func() { api.Inject(http.MethodPost, 2*time.Second) },
+				Config:      config("sales@example.com"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig: func() { api.Inject("", 0) },
+				Config:    config("sales@example.com"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodGet, 2*time.Second) },
+				Config:      config("sales@example.com"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodPut, 2*time.Second) },
+				Config:      config("other@example.com"),
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig:   func() { api.Inject(http.MethodDelete, 2*time.Second) },
+				Config:      config("other@example.com"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deadline exceeded"),
+			},
+			{
+				PreConfig: func() { api.Inject("", 0) },
+				Config:    config("other@example.com"),
+			},
+		},
+	})
+}
+
+func TestRewriteRuleResource_API_AdoptExisting(t *testing.T) {
+	testCases := map[string]struct {
+		provider string
+		resource string
+	}{
+		"resource": {
+			resource: "adopt_existing = true",
+		},
+		"provider": {
+			provider: "adopt_existing = true",
+		},
+		"resource-overrides-provider": {
+			provider: "adopt_existing = false",
+			resource: "adopt_existing = true",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(conflictOnCreate(simulator.MigaduAPI(t, &simulator.State{
+				Rewrites: []model.RewriteRule{
+					{
+						DomainName:    "example.com",
+						Name:          "sec",
+						LocalPartRule: "security-*",
+						Destinations:  []string{"someone@example.com"},
+					},
+				},
+			})))
+			defer server.Close()
+
+			config := func(adopt bool) string {
+				providerSettings, resourceSettings := "", ""
+				if adopt {
+					providerSettings, resourceSettings = testCase.provider, testCase.resource
+				}
+				return fmt.Sprintf(`
+					provider "migadu" {
+						username = "username"
+						token    = "token"
+						endpoint = "%s"
+						%s
+					}
+
+					resource "migadu_rewrite_rule" "test" {
+						domain_name     = "example.com"
+						name            = "sec"
+						local_part_rule = "sec-*"
+						destinations    = ["security@example.com"]
+						%s
+					}
+				`, server.URL, providerSettings, resourceSettings)
+			}
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      config(false),
+						ExpectError: regexp.MustCompile("Error Creating RewriteRule Rule: Conflict"),
+					},
+					{
+						Config: config(true),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_rewrite_rule.test", "local_part_rule", "sec-*"),
+							resource.TestCheckResourceAttr("migadu_rewrite_rule.test", "destinations.#", "1"),
+							resource.TestCheckResourceAttr("migadu_rewrite_rule.test", "destinations.0", "security@example.com"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
 func TestRewriteRuleResource_API_Errors(t *testing.T) {
 	testCases := map[string]APIErrorTestCase{
 		"error-409": {
@@ -0,0 +1,120 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+)
+
+// rewriteRuleContentEqual checks whether the given rule matches the given existing rule, ignoring its order number.
+func rewriteRuleContentEqual(ctx context.Context, rule RewriteRuleSetRuleModel, existing model.RewriteRule) bool {
+	if rule.LocalPartRule.ValueString() != existing.LocalPartRule {
+		return false
+	}
+	destinations, _ := custom_types.NewEmailAddressSetValueFrom(ctx, existing.Destinations)
+	equal, _ := rule.Destinations.SetSemanticEquals(ctx, destinations)
+	return equal
+}
+
+// planRewriteRules splits the given existing rewrite rules of a domain into the rules that are part of the given
+// rules, and the names of the rules to delete because they were previously listed or unlisted rules are deleted. It
+// returns the first of the contiguous order numbers of the given rules as well.
+func planRewriteRules(ctx context.Context, rules []RewriteRuleSetRuleModel, previousRules []RewriteRuleSetRuleModel, deleteUnlisted bool, rewrites []model.RewriteRule) (map[string]model.RewriteRule, []string, int64) {
+	planned := map[string]bool{}
+	for _, rule := range rules {
+		planned[rule.Name.ValueString()] = true
+	}
+	previous := map[string]bool{}
+	for _, rule := range previousRules {
+		previous[rule.Name.ValueString()] = true
+	}
+
+	existing := map[string]model.RewriteRule{}
+	var deleted []string
+	reserved := map[int64]bool{}
+	for _, rewrite := range rewrites {
+		if planned[rewrite.Name] {
+			existing[rewrite.Name] = rewrite
+			continue
+		}
+		if previous[rewrite.Name] || deleteUnlisted {
+			deleted = append(deleted, rewrite.Name)
+			continue
+		}
+		reserved[rewrite.OrderNum] = true
+	}
+
+	return existing, deleted, rewriteRuleOrderOffset(ctx, rules, existing, reserved)
+}
+
+// rewriteRuleOrderOffset returns the first order number of the contiguous order numbers of the given rules. The offset
+// is chosen so that as many unchanged rules as possible keep their existing order number and thus require no API call.
+// The range never includes the reserved order numbers of unlisted rules that are kept.
+func rewriteRuleOrderOffset(ctx context.Context, rules []RewriteRuleSetRuleModel, existing map[string]model.RewriteRule, reserved map[int64]bool) int64 {
+	available := func(offset int64) bool {
+		for index := range rules {
+			if reserved[offset+int64(index)] {
+				return false
+			}
+		}
+		return true
+	}
+
+	votes := map[int64]int{}
+	for index, rule := range rules {
+		if existingRule, ok := existing[rule.Name.ValueString()]; ok && rewriteRuleContentEqual(ctx, rule, existingRule) {
+			if offset := existingRule.OrderNum - int64(index); offset >= 0 && available(offset) {
+				votes[offset]++
+			}
+		}
+	}
+
+	var offset int64
+	var maxVotes int
+	for candidate, count := range votes {
+		if count > maxVotes || (count == maxVotes && candidate < offset) {
+			offset = candidate
+			maxVotes = count
+		}
+	}
+	for maxVotes == 0 && !available(offset) {
+		offset++
+	}
+	return offset
+}
+
+func RewriteRuleSetCreateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Creating Rewrite Rule Set", err, nil)
+}
+
+func RewriteRuleSetReadError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Reading Rewrite Rule Set", err, nil)
+}
+
+func RewriteRuleSetUpdateError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Updating Rewrite Rule Set", err, nil)
+}
+
+func RewriteRuleSetDeleteError(ctx context.Context, err error) diag.Diagnostics {
+	return APIErrorDiagnostics(ctx, "Error Deleting Rewrite Rule Set", err, nil)
+}
+
+func RewriteRuleSetImportError(id string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Rewrite Rule Set",
+		standardImportErrorDetail("domain_name", id),
+	)
+}
+
+func RewriteRuleSetImportIdentityError() diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Importing Rewrite Rule Set",
+		standardImportIdentityErrorDetail("domain_name"),
+	)
+}
@@ -0,0 +1,569 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/metio/migadu-client.go/client"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/terraform-provider-migadu/internal/provider/custom_types"
+	"net/http"
+	"slices"
+)
+
+var (
+	_ resource.Resource                   = (*RewriteRuleSetResource)(nil)
+	_ resource.ResourceWithConfigure      = (*RewriteRuleSetResource)(nil)
+	_ resource.ResourceWithImportState    = (*RewriteRuleSetResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*RewriteRuleSetResource)(nil)
+	_ resource.ResourceWithIdentity       = (*RewriteRuleSetResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*RewriteRuleSetResource)(nil)
+)
+
+func NewRewriteRuleSetResource() resource.Resource {
+	return &RewriteRuleSetResource{}
+}
+
+type RewriteRuleSetResource struct {
+	MigaduClient *client.MigaduClient
+	ReadCache    *ReadCache
+}
+
+type RewriteRuleSetResourceModel struct {
+	ID             custom_types.DomainNameValue `tfsdk:"id"`
+	DomainName     custom_types.DomainNameValue `tfsdk:"domain_name"`
+	Rules          []RewriteRuleSetRuleModel    `tfsdk:"rules"`
+	DeleteUnlisted types.Bool                   `tfsdk:"delete_unlisted"`
+}
+
+type RewriteRuleSetResourceIdentityModel struct {
+	DomainName types.String `tfsdk:"domain_name"`
+}
+
+type RewriteRuleSetRuleModel struct {
+	Name          types.String                      `tfsdk:"name"`
+	LocalPartRule types.String                      `tfsdk:"local_part_rule"`
+	OrderNum      types.Int64                       `tfsdk:"order_num"`
+	Destinations  custom_types.EmailAddressSetValue `tfsdk:"destinations"`
+}
+
+func (r *RewriteRuleSetResource) Metadata(_ context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_rewrite_rule_set"
+}
+
+func (r *RewriteRuleSetResource) Schema(_ context.Context, _ resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description:         "Provides the ordered rewrite rules of a domain. The rules are assigned contiguous order numbers in the order they are listed. Existing rules with the same name are taken over. Do not use this resource together with 'migadu_rewrite_rule' resources for the same rules.",
+		MarkdownDescription: "Provides the ordered rewrite rules of a domain. The rules are assigned contiguous order numbers in the order they are listed. Existing rules with the same name are taken over. Do not use this resource together with `migadu_rewrite_rule` resources for the same rules.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Same value as the 'domain_name' attribute.",
+				MarkdownDescription: "Same value as the `domain_name` attribute.",
+				Required:            false,
+				Optional:            false,
+				Computed:            true,
+				CustomType:          custom_types.DomainNameType{},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain_name": schema.StringAttribute{
+				Description:         "The domain name of the rewrite rules.",
+				MarkdownDescription: "The domain name of the rewrite rules.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				CustomType:          custom_types.DomainNameType{},
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rules": schema.ListNestedAttribute{
+				Description:         "The rewrite rules of the domain. The first rule will be executed first.",
+				MarkdownDescription: "The rewrite rules of the domain. The first rule will be executed first.",
+				Required:            true,
+				Optional:            false,
+				Computed:            false,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Description:         "The name (slug) of the rewrite rule.",
+							MarkdownDescription: "The name (slug) of the rewrite rule.",
+							Required:            true,
+							Optional:            false,
+							Computed:            false,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"local_part_rule": schema.StringAttribute{
+							Description:         "The rule matching the local part of incoming emails. The wildcard '*' matches any sequence of characters, including none, e.g. 'sec-*' matches 'sec-' and 'sec-team'.",
+							MarkdownDescription: "The rule matching the local part of incoming emails. The wildcard `*` matches any sequence of characters, including none, e.g. `sec-*` matches `sec-` and `sec-team`.",
+							Required:            true,
+							Optional:            false,
+							Computed:            false,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"order_num": schema.Int64Attribute{
+							Description:         "The order number assigned to the rewrite rule.",
+							MarkdownDescription: "The order number assigned to the rewrite rule.",
+							Required:            false,
+							Optional:            false,
+							Computed:            true,
+						},
+						"destinations": schema.SetAttribute{
+							Description:         "The destinations of the rewrite rule.",
+							MarkdownDescription: "The destinations of the rewrite rule.",
+							Required:            true,
+							Optional:            false,
+							Computed:            false,
+							CustomType: custom_types.EmailAddressSetType{
+								SetType: types.SetType{
+									ElemType: custom_types.EmailAddressType{},
+								},
+							},
+							Validators: []validator.Set{
+								setvalidator.SizeAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"delete_unlisted": schema.BoolAttribute{
+				Description:         "Whether rewrite rules of the domain that are not listed in 'rules' will be deleted. Unlisted rules that are kept retain their order numbers, which the listed rules avoid. Defaults to 'false'.",
+				MarkdownDescription: "Whether rewrite rules of the domain that are not listed in `rules` will be deleted. Unlisted rules that are kept retain their order numbers, which the listed rules avoid. Defaults to `false`.",
+				Required:            false,
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *RewriteRuleSetResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"domain_name": identityschema.StringAttribute{
+				Description:       "The domain name of the rewrite rules.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+func (r *RewriteRuleSetResource) Configure(_ context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	if request.ProviderData == nil {
+		return
+	}
+
+	if providerData, ok := request.ProviderData.(*MigaduProviderData); ok {
+		r.MigaduClient = providerData.Client
+		r.ReadCache = providerData.ReadCache
+	} else {
+		response.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *provider.MigaduProviderData, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+	}
+}
+
+func (r *RewriteRuleSetResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var rules types.List
+	response.Diagnostics.Append(request.Config.GetAttribute(ctx, path.Root("rules"), &rules)...)
+	if response.Diagnostics.HasError() || rules.IsNull() || rules.IsUnknown() {
+		return
+	}
+
+	var ruleModels []RewriteRuleSetRuleModel
+	response.Diagnostics.Append(rules.ElementsAs(ctx, &ruleModels, false)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	names := map[string]bool{}
+	for index, rule := range ruleModels {
+		if rule.Name.IsNull() || rule.Name.IsUnknown() {
+			continue
+		}
+		if names[rule.Name.ValueString()] {
+			response.Diagnostics.AddAttributeError(
+				path.Root("rules").AtListIndex(index).AtName("name"),
+				"Duplicate Rewrite Rule Name",
+				fmt.Sprintf("The rewrite rule name '%s' is used more than once", rule.Name.ValueString()),
+			)
+		}
+		names[rule.Name.ValueString()] = true
+	}
+}
+
+// ModifyPlan sets the order numbers the rules will be assigned, so that rules keeping their order number show no
+// difference. The order numbers stay unknown in case they depend on values that are not known yet.
+func (r *RewriteRuleSetResource) ModifyPlan(ctx context.Context, request resource.ModifyPlanRequest, response *resource.ModifyPlanResponse) {
+	if request.Plan.Raw.IsNull() || r.MigaduClient == nil {
+		return
+	}
+
+	var rules types.List
+	response.Diagnostics.Append(request.Plan.GetAttribute(ctx, path.Root("rules"), &rules)...)
+	if response.Diagnostics.HasError() || rules.IsNull() || rules.IsUnknown() {
+		return
+	}
+
+	var plan RewriteRuleSetResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+	if plan.DomainName.IsUnknown() || plan.DeleteUnlisted.IsUnknown() {
+		return
+	}
+	unknownOrder := false
+	for _, rule := range plan.Rules {
+		if rule.Name.IsUnknown() || rule.LocalPartRule.IsUnknown() || rule.Destinations.IsUnknown() {
+			return
+		}
+		for _, destination := range rule.Destinations.Elements() {
+			if destination.IsUnknown() {
+				return
+			}
+		}
+		unknownOrder = unknownOrder || rule.OrderNum.IsUnknown()
+	}
+	if !unknownOrder {
+		return
+	}
+
+	var state RewriteRuleSetResourceModel
+	if !request.State.Raw.IsNull() {
+		response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// the domain might be created in the same apply, therefore the order numbers are only known after apply
+	rewrites, err := r.MigaduClient.GetRewriteRules(ctx, plan.DomainName.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, "Cannot plan order numbers of rewrite rules", map[string]interface{}{
+			"domain_name": plan.DomainName.ValueString(),
+			"error":       err.Error(),
+		})
+		return
+	}
+
+	_, _, offset := planRewriteRules(ctx, plan.Rules, state.Rules, plan.DeleteUnlisted.ValueBool(), rewrites.RewriteRules)
+	for index := range plan.Rules {
+		plan.Rules[index].OrderNum = types.Int64Value(offset + int64(index))
+	}
+
+	response.Diagnostics.Append(response.Plan.Set(ctx, &plan)...)
+}
+
+func (r *RewriteRuleSetResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var plan RewriteRuleSetResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	response.Diagnostics.Append(r.apply(ctx, &plan, nil, RewriteRuleSetCreateError)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.DomainName
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newRewriteRuleSetResourceIdentityModel(&plan))...)
+}
+
+func (r *RewriteRuleSetResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state RewriteRuleSetResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	rewrites, err := r.MigaduClient.GetRewriteRules(ctx, state.DomainName.ValueString())
+	if err != nil {
+		var requestError *client.RequestError
+		if errors.As(err, &requestError) {
+			if requestError.StatusCode == http.StatusNotFound {
+				response.State.RemoveResource(ctx)
+				return
+			}
+		}
+		response.Diagnostics.Append(RewriteRuleSetReadError(ctx, err)...)
+		return
+	}
+
+	var rules []RewriteRuleSetRuleModel
+	listed := map[string]bool{}
+	for _, rule := range state.Rules {
+		listed[rule.Name.ValueString()] = true
+		for _, rewrite := range rewrites.RewriteRules {
+			if rewrite.Name == rule.Name.ValueString() {
+				rules = append(rules, r.toModel(ctx, rewrite, rule.Destinations, &response.Diagnostics))
+				break
+			}
+		}
+	}
+	if state.DeleteUnlisted.ValueBool() {
+		for _, rewrite := range rewrites.RewriteRules {
+			if !listed[rewrite.Name] {
+				rules = append(rules, r.toModel(ctx, rewrite, custom_types.NewEmailAddressSetNull(), &response.Diagnostics))
+			}
+		}
+	}
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// rules reordered outside Terraform show up as a difference in the order of the list
+	slices.SortStableFunc(rules, func(a, b RewriteRuleSetRuleModel) int {
+		return cmp.Compare(a.OrderNum.ValueInt64(), b.OrderNum.ValueInt64())
+	})
+
+	state.ID = state.DomainName
+	state.Rules = rules
+
+	response.Diagnostics.Append(response.State.Set(ctx, &state)...)
+	response.Diagnostics.Append(response.Identity.Set(ctx, newRewriteRuleSetResourceIdentityModel(&state))...)
+}
+
+func newRewriteRuleSetResourceIdentityModel(state *RewriteRuleSetResourceModel) RewriteRuleSetResourceIdentityModel {
+	return RewriteRuleSetResourceIdentityModel{
+		DomainName: types.StringValue(state.DomainName.ValueString()),
+	}
+}
+
+func (r *RewriteRuleSetResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var plan RewriteRuleSetResourceModel
+	response.Diagnostics.Append(request.Plan.Get(ctx, &plan)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(plan.DomainName.ValueString())
+
+	var state RewriteRuleSetResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	response.Diagnostics.Append(r.apply(ctx, &plan, state.Rules, RewriteRuleSetUpdateError)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = plan.DomainName
+
+	response.Diagnostics.Append(response.State.Set(ctx, plan)...)
+}
+
+func (r *RewriteRuleSetResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
+	var state RewriteRuleSetResourceModel
+	response.Diagnostics.Append(request.State.Get(ctx, &state)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	defer r.ReadCache.Invalidate(state.DomainName.ValueString())
+
+	for _, rule := range state.Rules {
+		_, err := r.MigaduClient.DeleteRewriteRule(ctx, state.DomainName.ValueString(), rule.Name.ValueString())
+		if err != nil {
+			var requestError *client.RequestError
+			if errors.As(err, &requestError) {
+				if requestError.StatusCode == http.StatusNotFound {
+					continue
+				}
+			}
+			response.Diagnostics.Append(RewriteRuleSetDeleteError(ctx, err)...)
+			return
+		}
+	}
+}
+
+func (r *RewriteRuleSetResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	if request.ID == "" && request.Identity != nil && !request.Identity.Raw.IsNull() {
+		var resourceIdentity RewriteRuleSetResourceIdentityModel
+		response.Diagnostics.Append(request.Identity.Get(ctx, &resourceIdentity)...)
+		if response.Diagnostics.HasError() {
+			return
+		}
+
+		domainName := resourceIdentity.DomainName.ValueString()
+
+		if domainName == "" {
+			response.Diagnostics.Append(RewriteRuleSetImportIdentityError())
+			return
+		}
+
+		tflog.Trace(ctx, "read import identity", map[string]interface{}{
+			"domain_name": domainName,
+		})
+
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("id"), domainName)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), domainName)...)
+		response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("delete_unlisted"), false)...)
+		response.Diagnostics.Append(r.importRules(ctx, domainName, response)...)
+		return
+	}
+
+	if request.ID == "" {
+		response.Diagnostics.Append(RewriteRuleSetImportError(request.ID))
+		return
+	}
+
+	tflog.Trace(ctx, "parsed import ID", map[string]interface{}{
+		"domain_name": request.ID,
+	})
+
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), request, response)
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("domain_name"), request.ID)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root("delete_unlisted"), false)...)
+	response.Diagnostics.Append(r.importRules(ctx, request.ID, response)...)
+}
+
+// importRules takes over all existing rules of the given domain in their current order.
+func (r *RewriteRuleSetResource) importRules(ctx context.Context, domainName string, response *resource.ImportStateResponse) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	rewrites, err := r.MigaduClient.GetRewriteRules(ctx, domainName)
+	if err != nil {
+		diags.Append(RewriteRuleSetReadError(ctx, err)...)
+		return diags
+	}
+
+	rules := []RewriteRuleSetRuleModel{}
+	for _, rewrite := range rewrites.RewriteRules {
+		rules = append(rules, r.toModel(ctx, rewrite, custom_types.NewEmailAddressSetNull(), &diags))
+	}
+	if diags.HasError() {
+		return diags
+	}
+	slices.SortStableFunc(rules, func(a, b RewriteRuleSetRuleModel) int {
+		return cmp.Compare(a.OrderNum.ValueInt64(), b.OrderNum.ValueInt64())
+	})
+
+	diags.Append(response.State.SetAttribute(ctx, path.Root("rules"), rules)...)
+	return diags
+}
+
+// apply deletes rules that are no longer wanted, and creates or updates the planned rules with the least amount of API
+// calls. The order numbers of the planned rules are set to their new values.
+func (r *RewriteRuleSetResource) apply(ctx context.Context, plan *RewriteRuleSetResourceModel, previousRules []RewriteRuleSetRuleModel, apiError func(context.Context, error) diag.Diagnostics) diag.Diagnostics {
+	var diags diag.Diagnostics
+	domainName := plan.DomainName.ValueString()
+
+	rewrites, err := r.MigaduClient.GetRewriteRules(ctx, domainName)
+	if err != nil {
+		diags.Append(apiError(ctx, err)...)
+		return diags
+	}
+
+	existing, deleted, offset := planRewriteRules(ctx, plan.Rules, previousRules, plan.DeleteUnlisted.ValueBool(), rewrites.RewriteRules)
+	for _, name := range deleted {
+		_, err = r.MigaduClient.DeleteRewriteRule(ctx, domainName, name)
+		if err != nil {
+			diags.Append(apiError(ctx, err)...)
+			return diags
+		}
+	}
+
+	for index := range plan.Rules {
+		rule := &plan.Rules[index]
+		orderNum := offset + int64(index)
+		rule.OrderNum = types.Int64Value(orderNum)
+
+		existingRule, ok := existing[rule.Name.ValueString()]
+		if ok && existingRule.OrderNum == orderNum && rewriteRuleContentEqual(ctx, *rule, existingRule) {
+			continue
+		}
+
+		var destinations []string
+		diags.Append(rule.Destinations.ElementsAs(ctx, &destinations, false)...)
+		if diags.HasError() {
+			return diags
+		}
+
+		rewrite := &model.RewriteRule{
+			Name:          rule.Name.ValueString(),
+			LocalPartRule: rule.LocalPartRule.ValueString(),
+			OrderNum:      orderNum,
+			Destinations:  destinations,
+		}
+
+		if ok {
+			_, err = r.MigaduClient.UpdateRewriteRule(ctx, domainName, rewrite.Name, rewrite)
+		} else {
+			_, err = r.MigaduClient.CreateRewriteRule(ctx, domainName, rewrite)
+		}
+		if err != nil {
+			diags.Append(apiError(ctx, err)...)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// toModel converts the given rewrite rule into its model. The given destinations are kept in case they are semantically
+// equal to the destinations of the rewrite rule.
+func (r *RewriteRuleSetResource) toModel(ctx context.Context, rewrite model.RewriteRule, destinations custom_types.EmailAddressSetValue, diags *diag.Diagnostics) RewriteRuleSetRuleModel {
+	receivedDestinations, d := custom_types.NewEmailAddressSetValueFrom(ctx, rewrite.Destinations)
+	diags.Append(d...)
+
+	if equal, _ := destinations.SetSemanticEquals(ctx, receivedDestinations); !equal {
+		destinations = receivedDestinations
+	}
+
+	return RewriteRuleSetRuleModel{
+		Name:          types.StringValue(rewrite.Name),
+		LocalPartRule: types.StringValue(rewrite.LocalPartRule),
+		OrderNum:      types.Int64Value(rewrite.OrderNum),
+		Destinations:  destinations,
+	}
+}
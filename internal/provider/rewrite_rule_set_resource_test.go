@@ -0,0 +1,573 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/migadu-client.go/model"
+	"github.com/metio/migadu-client.go/simulator"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestRewriteRuleSetResource_Schema(t *testing.T) {
+	ctx := context.Background()
+	schemaRequest := fwresource.SchemaRequest{}
+	schemaResponse := &fwresource.SchemaResponse{}
+
+	provider.NewRewriteRuleSetResource().Schema(ctx, schemaRequest, schemaResponse)
+
+	if schemaResponse.Diagnostics.HasError() {
+		t.Fatalf("Schema method diagnostics: %+v", schemaResponse.Diagnostics)
+	}
+
+	diagnostics := schemaResponse.Schema.ValidateImplementation(ctx)
+	if diagnostics.HasError() {
+		t.Fatalf("Schema validation diagnostics: %+v", diagnostics)
+	}
+}
+
+func TestRewriteRuleSetResource_API_Success(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	rule := func(name string) string {
+		return fmt.Sprintf(`
+			{
+				name            = "%s"
+				local_part_rule = "%s-*"
+				destinations    = ["%s@example.com"]
+			},
+		`, name, name, name)
+	}
+	ruleSet := func(rules ...string) string {
+		config := `
+			resource "migadu_rewrite_rule_set" "test" {
+				domain_name = "example.com"
+				rules       = [
+		`
+		for _, name := range rules {
+			config += rule(name)
+		}
+		return config + `
+				]
+			}
+		`
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + ruleSet("first", "second", "third"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "id", "example.com"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "delete_unlisted", "false"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.#", "3"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.0.name", "first"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.0.order_num", "0"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.1.name", "second"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.1.order_num", "1"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.2.name", "third"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.2.order_num", "2"),
+				),
+			},
+			{
+				ResourceName:      "migadu_rewrite_rule_set.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: providerConfig(server.URL) + ruleSet("third", "first", "second"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.#", "3"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.0.name", "third"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.0.order_num", "2"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.1.name", "first"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.1.order_num", "3"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.2.name", "second"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.2.order_num", "4"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + ruleSet("third", "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.#", "2"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.0.order_num", "2"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.1.order_num", "3"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + ruleSet("third", "first") + `
+					data "migadu_rewrite_rules" "test" {
+						domain_name = "example.com"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.migadu_rewrite_rules.test", "rewrites.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestRewriteRuleSetResource_API_Success_Existing_Rules(t *testing.T) {
+	testCases := map[string]struct {
+		deleteUnlisted bool
+		state          []model.RewriteRule
+		wantOrder      []string
+		wantRewrites   string
+	}{
+		"keep-unlisted": {
+			deleteUnlisted: false,
+			state: []model.RewriteRule{
+				{
+					DomainName:    "example.com",
+					Name:          "legacy",
+					LocalPartRule: "legacy-*",
+					OrderNum:      0,
+					Destinations:  []string{"legacy@example.com"},
+				},
+				{
+					DomainName:    "example.com",
+					Name:          "first",
+					LocalPartRule: "first-*",
+					OrderNum:      5,
+					Destinations:  []string{"first@example.com"},
+				},
+			},
+			wantOrder:    []string{"5", "6"},
+			wantRewrites: "3",
+		},
+		"unlisted-within-range": {
+			deleteUnlisted: false,
+			state: []model.RewriteRule{
+				{
+					DomainName:    "example.com",
+					Name:          "first",
+					LocalPartRule: "first-*",
+					OrderNum:      5,
+					Destinations:  []string{"first@example.com"},
+				},
+				{
+					DomainName:    "example.com",
+					Name:          "legacy",
+					LocalPartRule: "legacy-*",
+					OrderNum:      6,
+					Destinations:  []string{"legacy@example.com"},
+				},
+			},
+			wantOrder:    []string{"0", "1"},
+			wantRewrites: "3",
+		},
+		"delete-unlisted": {
+			deleteUnlisted: true,
+			state: []model.RewriteRule{
+				{
+					DomainName:    "example.com",
+					Name:          "legacy",
+					LocalPartRule: "legacy-*",
+					OrderNum:      0,
+					Destinations:  []string{"legacy@example.com"},
+				},
+				{
+					DomainName:    "example.com",
+					Name:          "first",
+					LocalPartRule: "first-*",
+					OrderNum:      5,
+					Destinations:  []string{"first@example.com"},
+				},
+			},
+			wantOrder:    []string{"5", "6"},
+			wantRewrites: "2",
+		},
+		"changed-content": {
+			deleteUnlisted: false,
+			state: []model.RewriteRule{
+				{
+					DomainName:    "example.com",
+					Name:          "first",
+					LocalPartRule: "changed-*",
+					OrderNum:      5,
+					Destinations:  []string{"first@example.com"},
+				},
+			},
+			wantOrder:    []string{"0", "1"},
+			wantRewrites: "2",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{Rewrites: testCase.state}))
+			defer server.Close()
+
+			config := providerConfig(server.URL) + fmt.Sprintf(`
+				resource "migadu_rewrite_rule_set" "test" {
+					domain_name     = "example.com"
+					delete_unlisted = %v
+					rules           = [
+						{
+							name            = "first"
+							local_part_rule = "first-*"
+							destinations    = ["first@example.com"]
+						},
+						{
+							name            = "second"
+							local_part_rule = "second-*"
+							destinations    = ["second@example.com"]
+						},
+					]
+				}
+			`, testCase.deleteUnlisted)
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.#", "2"),
+							resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.0.order_num", testCase.wantOrder[0]),
+							resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.1.order_num", testCase.wantOrder[1]),
+						),
+					},
+					{
+						Config: config + `
+							data "migadu_rewrite_rules" "test" {
+								domain_name = "example.com"
+							}
+						`,
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckResourceAttr("data.migadu_rewrite_rules.test", "rewrites.#", testCase.wantRewrites),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestRewriteRuleSetResource_API_Success_Planned_Order(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{
+		Rewrites: []model.RewriteRule{
+			{
+				DomainName:    "example.com",
+				Name:          "legacy",
+				LocalPartRule: "legacy-*",
+				OrderNum:      2,
+				Destinations:  []string{"legacy@example.com"},
+			},
+		},
+	}))
+	defer server.Close()
+
+	ruleSet := func(names ...string) string {
+		config := `
+			resource "migadu_rewrite_rule_set" "test" {
+				domain_name = "example.com"
+				rules       = [
+		`
+		for _, name := range names {
+			config += fmt.Sprintf(`
+				{
+					name            = "%s"
+					local_part_rule = "%s-*"
+					destinations    = ["%s@example.com"]
+				},
+			`, name, name, name)
+		}
+		return config + `
+				]
+			}
+		`
+	}
+	orderNum := func(index int) tfjsonpath.Path {
+		return tfjsonpath.New("rules").AtSliceIndex(index).AtMapKey("order_num")
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + ruleSet("first", "second"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("migadu_rewrite_rule_set.test", orderNum(0), knownvalue.Int64Exact(0)),
+						plancheck.ExpectKnownValue("migadu_rewrite_rule_set.test", orderNum(1), knownvalue.Int64Exact(1)),
+					},
+				},
+			},
+			{
+				Config: providerConfig(server.URL) + ruleSet("first", "second", "third"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("migadu_rewrite_rule_set.test", orderNum(0), knownvalue.Int64Exact(3)),
+						plancheck.ExpectKnownValue("migadu_rewrite_rule_set.test", orderNum(1), knownvalue.Int64Exact(4)),
+						plancheck.ExpectKnownValue("migadu_rewrite_rule_set.test", orderNum(2), knownvalue.Int64Exact(5)),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.0.order_num", "3"),
+					resource.TestCheckResourceAttr("migadu_rewrite_rule_set.test", "rules.2.order_num", "5"),
+				),
+			},
+			{
+				Config: providerConfig(server.URL) + ruleSet("first", "second", "third", "fourth"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectKnownValue("migadu_rewrite_rule_set.test", orderNum(0), knownvalue.Int64Exact(3)),
+						plancheck.ExpectKnownValue("migadu_rewrite_rule_set.test", orderNum(3), knownvalue.Int64Exact(6)),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestRewriteRuleSetResource_API_Success_Import_Existing_Rules(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{
+		Rewrites: []model.RewriteRule{
+			{
+				DomainName:    "example.com",
+				Name:          "second",
+				LocalPartRule: "second-*",
+				OrderNum:      4,
+				Destinations:  []string{"second@example.com"},
+			},
+			{
+				DomainName:    "example.com",
+				Name:          "first",
+				LocalPartRule: "first-*",
+				OrderNum:      1,
+				Destinations:  []string{"first@example.com"},
+			},
+		},
+	}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_rewrite_rule_set" "test" {
+						domain_name = "example.com"
+						rules       = [
+							{
+								name            = "first"
+								local_part_rule = "first-*"
+								destinations    = ["first@example.com"]
+							},
+						]
+					}
+				`,
+				ResourceName:  "migadu_rewrite_rule_set.test",
+				ImportState:   true,
+				ImportStateId: "example.com",
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 state, got %d", len(states))
+					}
+					for key, want := range map[string]string{
+						"rules.#":           "2",
+						"rules.0.name":      "first",
+						"rules.0.order_num": "1",
+						"rules.1.name":      "second",
+						"rules.1.order_num": "4",
+						"delete_unlisted":   "false",
+					} {
+						if got := states[0].Attributes[key]; got != want {
+							return fmt.Errorf("expected %s to be %q, got %q", key, want, got)
+						}
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestRewriteRuleSetResource_API_Success_Identity(t *testing.T) {
+	server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{}))
+	defer server.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig(server.URL) + `
+					resource "migadu_rewrite_rule_set" "test" {
+						domain_name = "example.com"
+						rules       = [
+							{
+								name            = "sales"
+								local_part_rule = "sales-*"
+								destinations    = ["sales@example.com"]
+							},
+						]
+					}
+				`,
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("migadu_rewrite_rule_set.test", map[string]knownvalue.Check{
+						"domain_name": knownvalue.StringExact("example.com"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "migadu_rewrite_rule_set.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+		},
+	})
+}
+
+func TestRewriteRuleSetResource_API_Errors(t *testing.T) {
+	testCases := map[string]APIErrorTestCase{
+		"error-404": {
+			StatusCode: http.StatusNotFound,
+			ErrorRegex: "GetRewriteRules: status: 404",
+		},
+		"error-500": {
+			StatusCode: http.StatusInternalServerError,
+			ErrorRegex: "GetRewriteRules: status: 500",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(simulator.MigaduAPI(t, &simulator.State{StatusCode: testCase.StatusCode}))
+			defer server.Close()
+
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig(server.URL) + `
+							resource "migadu_rewrite_rule_set" "test" {
+								domain_name = "example.com"
+								rules       = [
+									{
+										name            = "first"
+										local_part_rule = "first-*"
+										destinations    = ["first@example.com"]
+									},
+								]
+							}
+						`,
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestRewriteRuleSetResource_Configuration_Errors(t *testing.T) {
+	testCases := map[string]ConfigurationErrorTestCase{
+		"empty-domain-name": {
+			Configuration: `
+				domain_name = ""
+				rules       = [
+					{
+						name            = "first"
+						local_part_rule = "first-*"
+						destinations    = ["first@example.com"]
+					},
+				]
+			`,
+			ErrorRegex: "Attribute domain_name string length must be at least 1",
+		},
+		"empty-rules": {
+			Configuration: `
+				domain_name = "example.com"
+				rules       = []
+			`,
+			ErrorRegex: "Attribute rules list must contain at least 1 elements",
+		},
+		"missing-rules": {
+			Configuration: `
+				domain_name = "example.com"
+			`,
+			ErrorRegex: `The argument "rules" is required, but no definition was found`,
+		},
+		"duplicate-names": {
+			Configuration: `
+				domain_name = "example.com"
+				rules       = [
+					{
+						name            = "first"
+						local_part_rule = "first-*"
+						destinations    = ["first@example.com"]
+					},
+					{
+						name            = "first"
+						local_part_rule = "second-*"
+						destinations    = ["second@example.com"]
+					},
+				]
+			`,
+			ErrorRegex: "The rewrite rule name 'first' is used more than once",
+		},
+		"empty-destinations": {
+			Configuration: `
+				domain_name = "example.com"
+				rules       = [
+					{
+						name            = "first"
+						local_part_rule = "first-*"
+						destinations    = []
+					},
+				]
+			`,
+			ErrorRegex: "set must contain at least 1 elements",
+		},
+		"invalid-destination": {
+			Configuration: `
+				domain_name = "example.com"
+				rules       = [
+					{
+						name            = "first"
+						local_part_rule = "first-*"
+						destinations    = ["first"]
+					},
+				]
+			`,
+			ErrorRegex: "An email must match the format 'local_part@domain'",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: providerConfig("https://localhost:12345") + fmt.Sprintf(`
+							resource "migadu_rewrite_rule_set" "test" {
+								%s
+							}
+						`, testCase.Configuration),
+						ExpectError: regexp.MustCompile(testCase.ErrorRegex),
+					},
+				},
+			})
+		})
+	}
+}
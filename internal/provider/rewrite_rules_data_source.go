@@ -144,6 +144,8 @@ func (d *RewriteRulesDataSource) Configure(_ context.Context, request datasource
 }
 
 func (d *RewriteRulesDataSource) Read(ctx context.Context, request datasource.ReadRequest, response *datasource.ReadResponse) {
+	ctx = WithAPIErrorRecorder(ctx)
+
 	var data RewriteRulesDataSourceModel
 	response.Diagnostics.Append(request.Config.Get(ctx, &data)...)
 	if response.Diagnostics.HasError() {
@@ -152,7 +154,7 @@ func (d *RewriteRulesDataSource) Read(ctx context.Context, request datasource.Re
 
 	rewrites, err := d.MigaduClient.GetRewriteRules(ctx, data.DomainName.ValueString())
 	if err != nil {
-		response.Diagnostics.Append(RewriteRuleReadError(err))
+		response.Diagnostics.Append(RewriteRuleReadError(ctx, err)...)
 		return
 	}
 
@@ -0,0 +1,54 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// rawStateUnmarshalOpts ignores attributes of previous schema versions that no longer exist in the current schema,
+// e.g. the removed '*_punycode' attributes.
+var rawStateUnmarshalOpts = tfprotov6.UnmarshalOpts{
+	ValueFromJSONOpts: tftypes.ValueFromJSONOpts{
+		IgnoreUndefinedAttributes: true,
+	},
+}
+
+// upgradeStateFromRawState upgrades state written with a previous schema version by reading its raw JSON with the
+// current schema. Removed attributes are dropped, missing attributes are set to null, and lists are read as sets
+// wherever the current schema declares a set. Use it for all schema versions whose remaining attributes still have
+// a compatible type.
+func upgradeStateFromRawState(ctx context.Context, request resource.UpgradeStateRequest, response *resource.UpgradeStateResponse) {
+	state, err := request.RawState.UnmarshalWithOpts(response.State.Schema.Type().TerraformType(ctx), rawStateUnmarshalOpts)
+	if err != nil {
+		response.Diagnostics.Append(StateUpgradeError(err))
+		return
+	}
+
+	response.State.Raw = state
+}
+
+func StateUpgradeError(err error) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Upgrading Resource State",
+		"Could not read the previously saved state with the current schema. "+
+			"Please contact the provider developer if you are unsure how to resolve the error.\n\n"+
+			"Error: "+err.Error(),
+	)
+}
+
+func StateMoveError(sourceTypeName string, err error) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		"Error Moving Resource State",
+		"Could not read the state of the '"+sourceTypeName+"' resource with the current schema. "+
+			"Please contact the provider developer if you are unsure how to resolve the error.\n\n"+
+			"Error: "+err.Error(),
+	)
+}
@@ -0,0 +1,30 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"time"
+)
+
+// defaultResourceTimeout bounds resource operations whose 'timeouts' block does not configure a timeout. It leaves
+// enough room for the default number of retries including their backoff.
+const defaultResourceTimeout = 5 * time.Minute
+
+// nullResourceTimeouts returns an unset 'timeouts' block for resource state that is not created from a plan, e.g. in
+// list results.
+func nullResourceTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
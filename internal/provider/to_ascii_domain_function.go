@@ -0,0 +1,57 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+var (
+	_ function.Function = (*ToASCIIDomainFunction)(nil)
+)
+
+func NewToASCIIDomainFunction() function.Function {
+	return &ToASCIIDomainFunction{}
+}
+
+type ToASCIIDomainFunction struct{}
+
+func (f *ToASCIIDomainFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "to_ascii_domain"
+}
+
+func (f *ToASCIIDomainFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Converts a domain name to its ASCII representation.",
+		Description:         "Converts a domain name to its lowercase ASCII (punycode) representation, e.g. 'bücher.example' becomes 'xn--bcher-kva.example'. Domain names that convert to the same value are considered equal by all resources of this provider.",
+		MarkdownDescription: "Converts a domain name to its lowercase ASCII (punycode) representation, e.g. `bücher.example` becomes `xn--bcher-kva.example`. Domain names that convert to the same value are considered equal by all resources of this provider.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				Description:         "The domain name to convert.",
+				MarkdownDescription: "The domain name to convert.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ToASCIIDomainFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var domain string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &domain))
+	if response.Error != nil {
+		return
+	}
+
+	normalized, funcErr := normalizeDomainArgument(0, domain)
+	if funcErr != nil {
+		response.Error = funcErr
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, normalized))
+}
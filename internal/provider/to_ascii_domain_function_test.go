@@ -0,0 +1,121 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	fwfunction "github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/metio/terraform-provider-migadu/internal/provider"
+	"regexp"
+	"testing"
+)
+
+func TestToASCIIDomainFunction_Definition(t *testing.T) {
+	ctx := context.Background()
+	definitionRequest := fwfunction.DefinitionRequest{}
+	definitionResponse := &fwfunction.DefinitionResponse{}
+
+	provider.NewToASCIIDomainFunction().Definition(ctx, definitionRequest, definitionResponse)
+
+	if definitionResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition method diagnostics: %+v", definitionResponse.Diagnostics)
+	}
+
+	validateResponse := &fwfunction.DefinitionValidateResponse{}
+	definitionResponse.Definition.ValidateImplementation(ctx, fwfunction.DefinitionValidateRequest{FuncName: "to_ascii_domain"}, validateResponse)
+	if validateResponse.Diagnostics.HasError() {
+		t.Fatalf("Definition validation diagnostics: %+v", validateResponse.Diagnostics)
+	}
+}
+
+func TestToASCIIDomainFunction_Success(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  string
+	}{
+		"ascii": {
+			input: "example.com",
+			want:  "example.com",
+		},
+		"uppercase": {
+			input: "Example.COM",
+			want:  "example.com",
+		},
+		"idna": {
+			input: "hoß.de",
+			want:  "xn--ho-hia.de",
+		},
+		"idna-punycode": {
+			input: "xn--ho-hia.de",
+			want:  "xn--ho-hia.de",
+		},
+		"idna-uppercase": {
+			input: "Bücher.Example",
+			want:  "xn--bcher-kva.example",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::migadu::to_ascii_domain("%s")
+							}
+						`, testCase.input),
+						Check: resource.ComposeAggregateTestCheckFunc(
+							resource.TestCheckOutput("test", testCase.want),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestToASCIIDomainFunction_Errors(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  string
+	}{
+		"whitespace": {
+			input: ` example.com `,
+			want:  `Could not convert domain name ' example\.com '`,
+		},
+		"wildcard": {
+			input: `*.example.com`,
+			want:  `Could not convert domain name '\*\.example\.com'`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			resource.UnitTest(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+					tfversion.SkipBelow(tfversion.Version1_8_0),
+				},
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+							output "test" {
+								value = provider::migadu::to_ascii_domain("%s")
+							}
+						`, testCase.input),
+						ExpectError: regexp.MustCompile(testCase.want),
+					},
+				},
+			})
+		})
+	}
+}
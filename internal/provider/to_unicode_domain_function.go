@@ -0,0 +1,64 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"golang.org/x/net/idna"
+)
+
+var (
+	_ function.Function = (*ToUnicodeDomainFunction)(nil)
+)
+
+func NewToUnicodeDomainFunction() function.Function {
+	return &ToUnicodeDomainFunction{}
+}
+
+type ToUnicodeDomainFunction struct{}
+
+func (f *ToUnicodeDomainFunction) Metadata(_ context.Context, _ function.MetadataRequest, response *function.MetadataResponse) {
+	response.Name = "to_unicode_domain"
+}
+
+func (f *ToUnicodeDomainFunction) Definition(_ context.Context, _ function.DefinitionRequest, response *function.DefinitionResponse) {
+	response.Definition = function.Definition{
+		Summary:             "Converts a domain name to its Unicode representation.",
+		Description:         "Converts a domain name to its lowercase Unicode representation, e.g. 'xn--bcher-kva.example' becomes 'bücher.example'. The result is semantically equal to the given domain name.",
+		MarkdownDescription: "Converts a domain name to its lowercase Unicode representation, e.g. `xn--bcher-kva.example` becomes `bücher.example`. The result is semantically equal to the given domain name.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				Description:         "The domain name to convert.",
+				MarkdownDescription: "The domain name to convert.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ToUnicodeDomainFunction) Run(ctx context.Context, request function.RunRequest, response *function.RunResponse) {
+	var domain string
+	response.Error = function.ConcatFuncErrors(response.Error, request.Arguments.Get(ctx, &domain))
+	if response.Error != nil {
+		return
+	}
+
+	normalized, funcErr := normalizeDomainArgument(0, domain)
+	if funcErr != nil {
+		response.Error = funcErr
+		return
+	}
+
+	unicode, err := idna.ToUnicode(normalized)
+	if err != nil {
+		response.Error = DomainArgumentError(0, domain, err)
+		return
+	}
+
+	response.Error = function.ConcatFuncErrors(response.Error, response.Result.Set(ctx, unicode))
+}
@@ -0,0 +1,144 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// NewMigaduTransport wraps the given transport with the retry, limit, timeout, request field and error handling of this
+// provider. Each attempt of a request waits for the account limiter, while the timeout only applies to the time spent on
+// the API.
+func NewMigaduTransport(next http.RoundTripper, retrySettings RetrySettings, limiter *AccountLimiter, timeout time.Duration) http.RoundTripper {
+	return NewAPIErrorTransport(NewRequestFieldsTransport(NewRetryTransport(NewLimitTransport(NewTimeoutTransport(next, timeout), limiter), retrySettings)))
+}
+
+// maxAPIErrorBodySize bounds the part of failed API responses kept for error diagnostics.
+const maxAPIErrorBodySize = 64 << 10
+
+// NewAPIErrorTransport wraps the given transport and records the body of failed responses in the context of their
+// request, see WithAPIErrorRecorder. Requests without a recorder pass through unchanged.
+func NewAPIErrorTransport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &apiErrorTransport{
+		next: next,
+	}
+}
+
+type apiErrorTransport struct {
+	next http.RoundTripper
+}
+
+func (t *apiErrorTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.next.RoundTrip(request)
+	if err != nil || response.StatusCode < http.StatusBadRequest {
+		return response, err
+	}
+	recorder, ok := request.Context().Value(apiErrorRecorderKey{}).(*apiErrorRecorder)
+	if !ok {
+		return response, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxAPIErrorBodySize))
+	closeErr := response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if closeErr != nil {
+		return nil, closeErr
+	}
+	recorder.record(response.StatusCode, body)
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	return response, nil
+}
+
+// HTTPTransportSettings configures the connections to the Migadu API.
+type HTTPTransportSettings struct {
+	// ProxyURL is the proxy to send all requests through. The proxy configured by the environment is used if nil.
+	ProxyURL *url.URL
+	// RootCAs contains the certificate authorities to trust. The certificate authorities of the system are used if nil.
+	RootCAs *x509.CertPool
+	// Certificates are presented to the server, e.g. to authenticate against a TLS-intercepting proxy.
+	Certificates []tls.Certificate
+	// InsecureSkipVerify disables the verification of the certificate presented by the server.
+	InsecureSkipVerify bool
+}
+
+// NewHTTPTransport returns a transport that connects to the Migadu API according to the given settings.
+func NewHTTPTransport(settings HTTPTransportSettings) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if settings.ProxyURL != nil {
+		transport.Proxy = http.ProxyURL(settings.ProxyURL)
+	}
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		RootCAs:            settings.RootCAs,
+		Certificates:       settings.Certificates,
+		InsecureSkipVerify: settings.InsecureSkipVerify,
+	}
+	return transport
+}
+
+// NewCertPool returns a copy of the certificate authorities of the system, or an empty pool if they are unavailable.
+func NewCertPool() *x509.CertPool {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return x509.NewCertPool()
+	}
+	return pool
+}
+
+// NewTimeoutTransport wraps the given transport and bounds each request by the given timeout. The timeout stays
+// active until the response body is closed. Set the timeout to 0 to disable it.
+func NewTimeoutTransport(next http.RoundTripper, timeout time.Duration) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &timeoutTransport{
+		next:    next,
+		timeout: timeout,
+	}
+}
+
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if t.timeout <= 0 {
+		return t.next.RoundTrip(request)
+	}
+
+	ctx, cancel := context.WithTimeout(request.Context(), t.timeout)
+	response, err := t.next.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	response.Body = &cancelOnCloseBody{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
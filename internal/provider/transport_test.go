@@ -0,0 +1,234 @@
+/*
+ * SPDX-FileCopyrightText: The terraform-provider-migadu Authors
+ * SPDX-License-Identifier: 0BSD
+ */
+
+package provider_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	internal "github.com/metio/terraform-provider-migadu/internal/provider"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimeoutTransport(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{
+		Transport: internal.NewRetryTransport(internal.NewTimeoutTransport(http.DefaultTransport, 100*time.Millisecond), internal.RetrySettings{
+			MaxRetries: 1,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: time.Millisecond,
+		}),
+	}
+
+	response, err := httpClient.Get(server.URL)
+	assert.NoError(t, err, "Get")
+	body, err := io.ReadAll(response.Body)
+	assert.NoError(t, err, "ReadAll")
+	assert.NoError(t, response.Body.Close(), "Close")
+
+	assert.Equal(t, "ok", string(body), "body")
+	assert.Equal(t, int32(2), calls.Load(), "calls")
+}
+
+func TestMigaduTransport_TimeoutExcludesLimits(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(60 * time.Millisecond)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	limiter := internal.NewAccountLimiter(internal.AccountLimitSettings{
+		MaxConcurrentRequests: 1,
+	})
+	httpClient := &http.Client{
+		Transport: internal.NewMigaduTransport(http.DefaultTransport, internal.RetrySettings{}, limiter, 100*time.Millisecond),
+	}
+
+	errs := make(chan error, 4)
+	for range 4 {
+		go func() {
+			response, err := httpClient.Get(server.URL)
+			if err == nil {
+				_, err = io.ReadAll(response.Body)
+				_ = response.Body.Close()
+			}
+			errs <- err
+		}()
+	}
+	for range 4 {
+		assert.NoError(t, <-errs, "Get")
+	}
+}
+
+func TestHTTPTransport_TLS(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+
+	serverCAs := internal.NewCertPool()
+	serverCAs.AppendCertsFromPEM(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	testCases := map[string]struct {
+		settings internal.HTTPTransportSettings
+		wantErr  bool
+	}{
+		"system-cas": {
+			settings: internal.HTTPTransportSettings{},
+			wantErr:  true,
+		},
+		"ca-bundle": {
+			settings: internal.HTTPTransportSettings{RootCAs: serverCAs},
+			wantErr:  false,
+		},
+		"insecure-skip-verify": {
+			settings: internal.HTTPTransportSettings{InsecureSkipVerify: true},
+			wantErr:  false,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			httpClient := &http.Client{Transport: internal.NewHTTPTransport(testCase.settings)}
+			response, err := httpClient.Get(server.URL)
+			if testCase.wantErr {
+				var verificationError *tls.CertificateVerificationError
+				assert.ErrorAs(t, err, &verificationError, "Get")
+				return
+			}
+			if assert.NoError(t, err, "Get") {
+				assert.NoError(t, response.Body.Close(), "Close")
+				assert.Equal(t, http.StatusOK, response.StatusCode, "StatusCode")
+			}
+		})
+	}
+}
+
+func TestHTTPTransport_ClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	certificate := newTestClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(certificate.Leaf)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCAs,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	withoutCertificate := &http.Client{Transport: internal.NewHTTPTransport(internal.HTTPTransportSettings{
+		InsecureSkipVerify: true,
+	})}
+	_, err := withoutCertificate.Get(server.URL)
+	assert.Error(t, err, "Get without certificate")
+
+	withCertificate := &http.Client{Transport: internal.NewHTTPTransport(internal.HTTPTransportSettings{
+		InsecureSkipVerify: true,
+		Certificates:       []tls.Certificate{certificate},
+	})}
+	response, err := withCertificate.Get(server.URL)
+	if assert.NoError(t, err, "Get with certificate") {
+		body, err := io.ReadAll(response.Body)
+		assert.NoError(t, err, "ReadAll")
+		assert.NoError(t, response.Body.Close(), "Close")
+		assert.Equal(t, "terraform-provider-migadu", string(body), "body")
+	}
+}
+
+func TestHTTPTransport_Proxy(t *testing.T) {
+	t.Parallel()
+
+	var proxied atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Store(r.URL.String())
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+
+	httpClient := &http.Client{Transport: internal.NewHTTPTransport(internal.HTTPTransportSettings{
+		ProxyURL: proxyURL,
+	})}
+	response, err := httpClient.Get("http://api.migadu.invalid/v1/domains")
+	if assert.NoError(t, err, "Get") {
+		assert.NoError(t, response.Body.Close(), "Close")
+		assert.Equal(t, http.StatusOK, response.StatusCode, "StatusCode")
+	}
+	assert.Equal(t, "http://api.migadu.invalid/v1/domains", proxied.Load(), "proxied URL")
+}
+
+func newTestClientCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-provider-migadu"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate: %v", err)
+	}
+	return tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+}